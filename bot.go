@@ -0,0 +1,233 @@
+//Participating Game of Drones by CodinGame - Bot interface and its adapters
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	DEFAULT_BOT_ADDRESS = "localhost:8080" //Address the HTTP adapter listens to by default
+)
+
+var botMutex sync.Mutex //The bot keeps the game in global variables, so the HTTP adapter answers one query at a time
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* DATA TYPES BEGIN */
+
+//A player of Game of Drones
+type Bot interface {
+	Init(board botBoard) error        //Starts a game in the given board
+	Turn(t botTurn) (botMoves, error) //Returns the moves of the given turn of the game
+}
+
+//Board of a game as the bots receive it
+type botBoard struct {
+	NumPlayers int       `json:"numPlayers"` //Number of players in the game
+	Whoami     int       `json:"whoami"`     //Index of the bot's player
+	NumDrones  int       `json:"numDrones"`  //Number of drones each player has
+	Zones      [][2]int  `json:"zones"`      //Centre of each zone
+	Rules      gameRules `json:"rules"`      //Rules of the game. The official ones if omitted
+}
+
+//Turn of a game as the bots receive it
+type botTurn struct {
+	Owners []int      `json:"owners"` //Owner of each zone (-1 if nobody owns it)
+	Drones [][][2]int `json:"drones"` //Position of each drone of each player
+}
+
+//Answer of a bot to a turn
+type botMoves struct {
+	Moves [][2]int `json:"moves"` //Destination of each of the bot's drones
+	Notes []string `json:"notes"` //Reasons of the moves
+}
+
+//Query of the HTTP adapter: what the bot would do in the given state
+type botQuery struct {
+	Board  botBoard `json:"board"`  //Board of the game
+	State  botTurn  `json:"state"`  //Owners and drones of the turn
+	Turn   int      `json:"turn"`   //Number of the turn (1 if it is not given)
+	Scores []int    `json:"scores"` //Score of each player (the zones of the turn if it is not given)
+}
+
+//The bot of this program. Only one can play at a time because the game is kept in global variables
+type gameBot struct{}
+
+/* DATA TYPES END ******************************************************************** BOT BEGIN */
+
+//Starts a game in the given board
+func (gameBot) Init(board botBoard) error {
+	return setBoard(board)
+}
+
+//Returns the moves of the given turn of the game
+func (gameBot) Turn(t botTurn) (botMoves, error) {
+	if err := setTurn(t); err != nil {
+		return botMoves{}, err
+	}
+	decideMoves()
+	if !safeRun("move invariants", enforceMoveInvariants) {
+		completeMoves()
+	}
+	return currentBotMoves(), nil
+}
+
+//Returns the moves decided in the current turn and their reasons
+func currentBotMoves() botMoves {
+	return botMoves{toPairs(nextMove), append([]string{}, turnNotes...)}
+}
+
+//Starts a new game in the board
+func setBoard(b botBoard) error {
+	if b.NumPlayers <= 0 || b.NumPlayers > MAX_PLAYERS || b.Whoami < 0 || b.Whoami >= b.NumPlayers || b.NumDrones <= 0 || b.NumDrones > MAX_DRONES_PER_PLAYER || len(b.Zones) == 0 || len(b.Zones) > MAX_ZONES {
+		return fmt.Errorf("wrong board: %d players, player %d, %d drones, %d zones", b.NumPlayers, b.Whoami, b.NumDrones, len(b.Zones))
+	}
+	r, err := completeRules(b.Rules)
+	if err != nil {
+		return err
+	}
+	for zId, z := range b.Zones {
+		if !r.inside(z) {
+			return fmt.Errorf("wrong board: zone %d out of the board in %v", zId, z)
+		}
+	}
+	rules = r
+	turn = 0
+	panicCount = 0
+	previousDrones = nil
+	attackPlans = nil
+	numPlayers, whoami, numDronesPerplayer, numZones = b.NumPlayers, b.Whoami, b.NumDrones, len(b.Zones)
+	players = make([]player, numPlayers)
+	for i, _ := range players {
+		players[i].drones = make([]point, numDronesPerplayer)
+	}
+	zones = make([]zone, numZones)
+	for i, z := range b.Zones {
+		zones[i] = newZone()
+		zones[i].pos = point{z[0], z[1]}
+	}
+	initializeBoard()
+	return nil
+}
+
+//Moves the game to the next turn: sets the owners and the drones and adds the points of the turn
+func setTurn(t botTurn) error {
+	if len(t.Owners) != numZones || len(t.Drones) != numPlayers {
+		return fmt.Errorf("wrong turn: %d owners and %d players", len(t.Owners), len(t.Drones))
+	}
+	for zId, owner := range t.Owners {
+		if owner < UNRECLAIMED || owner >= numPlayers {
+			return fmt.Errorf("wrong owner of zone %d: %d", zId, owner)
+		}
+	}
+	for pId, drones := range t.Drones {
+		if len(drones) != numDronesPerplayer {
+			return fmt.Errorf("wrong turn: %d drones of player %d", len(drones), pId)
+		}
+		for dId, d := range drones {
+			if !rules.inside(d) {
+				return fmt.Errorf("wrong turn: drone %d of player %d out of the board in %v", dId, pId, d)
+			}
+		}
+	}
+	turn++
+	turnNotes = nil
+	if turn > 1 {
+		rememberPositions()
+	}
+	for i, _ := range zones {
+		zones[i].owner = t.Owners[i]
+		if zones[i].owner >= 0 {
+			players[zones[i].owner].score += 1
+		}
+	}
+	for i, _ := range players {
+		for j, _ := range players[i].drones {
+			players[i].drones[j] = point{t.Drones[i][j][0], t.Drones[i][j][1]}
+		}
+	}
+	if turn == 1 {
+		analyzeStartPositions()
+	}
+	return nil
+}
+
+/* BOT END ******************************************************************** ADAPTERS BEGIN */
+
+//Returns what the bot would do in the state of the query. The turn and the scores of the query replace the ones the
+//bot would have after playing it as the first turn
+func (bot gameBot) query(q botQuery) (botMoves, error) {
+	if err := bot.Init(q.Board); err != nil {
+		return botMoves{}, err
+	}
+	if err := setTurn(q.State); err != nil {
+		return botMoves{}, err
+	}
+	if q.Turn > 0 {
+		turn = q.Turn
+	}
+	if q.Scores != nil {
+		if len(q.Scores) != numPlayers {
+			return botMoves{}, fmt.Errorf("wrong scores: %d players", len(q.Scores))
+		}
+		for pId, score := range q.Scores {
+			players[pId].score = score
+		}
+	}
+	decideMoves()
+	if !safeRun("move invariants", enforceMoveInvariants) {
+		completeMoves()
+	}
+	return currentBotMoves(), nil
+}
+
+//Answers the query while holding botMutex, so a panic cannot leave it locked
+func lockedQuery(q botQuery) (botMoves, error) {
+	botMutex.Lock()
+	defer botMutex.Unlock()
+	return gameBot{}.query(q)
+}
+
+//Answers POST queries (see botQuery) with the moves of the bot (see botMoves) as JSON
+func botHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST a query", http.StatusMethodNotAllowed)
+		return
+	}
+	var q botQuery
+	if err := json.NewDecoder(r.Body).Decode(&q); err != nil {
+		http.Error(w, "wrong query: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	moves, err := lockedQuery(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(moves)
+}
+
+//Serves the bot over HTTP in the given address (DEFAULT_BOT_ADDRESS by default)
+func commandServe(args []string) int {
+	if len(args) > 1 {
+		return EXIT_USAGE
+	}
+	address := DEFAULT_BOT_ADDRESS
+	if len(args) == 1 {
+		address = args[0]
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/turn", botHandler)
+	fmt.Fprintln(os.Stderr, "Serving the bot in http://"+address+"/turn")
+	if err := http.ListenAndServe(address, mux); err != nil {
+		fmt.Fprintln(os.Stderr, "Error serving the bot:", err)
+		return EXIT_ERROR
+	}
+	return EXIT_OK
+}
+
+/* ADAPTERS END */
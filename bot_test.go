@@ -0,0 +1,111 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+//Board and first turn of testInputs/minimax/input.txt
+var testBotBoard = botBoard{2, 0, 2, [][2]int{{500, 500}, {3500, 1300}}, gameRules{}}
+var testBotTurn = botTurn{[]int{-1, 1}, [][][2]int{{{1000, 500}, {1000, 600}}, {{3500, 1300}, {3000, 1500}}}}
+
+//Tests that the in-memory bot moves as the bot that reads the same game from the standard input
+func TestGameBot(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	decideMoves()
+	expected := toPairs(nextMove)
+	var bot Bot = gameBot{}
+	if err := bot.Init(testBotBoard); err != nil {
+		t.Fatal("Error starting the game:", err)
+	}
+	moves, err := bot.Turn(testBotTurn)
+	if err != nil {
+		t.Fatal("Error playing the turn:", err)
+	}
+	if len(moves.Moves) != len(expected) || len(moves.Notes) == 0 {
+		t.Fatal("Wrong moves:", moves)
+	}
+	for dId, m := range moves.Moves {
+		if m != expected[dId] {
+			t.Error("Wrong move of drone", dId, "Got", m, "Expected", expected[dId])
+		}
+	}
+	if _, err := bot.Turn(testBotTurn); err != nil || turn != 2 || players[1].score != 2 {
+		t.Error("The second turn should be played too", err, turn, players[1].score)
+	}
+}
+
+//Tests that wrong boards and turns are rejected
+func TestGameBotErrors(t *testing.T) {
+	bot := gameBot{}
+	for _, b := range []botBoard{{0, 0, 2, testBotBoard.Zones, gameRules{}}, {2, 2, 2, testBotBoard.Zones, gameRules{}},
+		{2, 0, 0, testBotBoard.Zones, gameRules{}}, {2, 0, 2, nil, gameRules{}}, {2, 0, 2, testBotBoard.Zones, gameRules{Width: 100}}} {
+		if err := bot.Init(b); err == nil {
+			t.Error("Wrong board should be rejected:", b)
+		}
+	}
+	bot.Init(testBotBoard)
+	wrongTurns := []botTurn{
+		{[]int{-1}, testBotTurn.Drones},
+		{[]int{-1, 2}, testBotTurn.Drones},
+		{[]int{-2, 0}, testBotTurn.Drones},
+		{testBotTurn.Owners, [][][2]int{{{1, 1}}, {{2, 2}, {3, 3}}}},
+	}
+	for _, wrong := range wrongTurns {
+		if _, err := bot.Turn(wrong); err == nil {
+			t.Error("Wrong turn should be rejected:", wrong)
+		}
+	}
+	if turn != 0 {
+		t.Error("Wrong turns should not be played. Turn:", turn)
+	}
+}
+
+//Tests that the standard input adapter does not play wrong or incomplete boards
+func TestStandardInputWrongBoard(t *testing.T) {
+	testCases := []string{
+		"",
+		"2 0 2",
+		"2 0 2 2\n500 500\n",
+		"0 0 2 1\n500 500\n-1\n",
+	}
+	for i, testCase := range testCases {
+		inputReader = strings.NewReader(testCase)
+		if output := captureOutput(letTheGameBegin, t); output != "" {
+			t.Error("Error in item", i, "Nothing should be played. Got", output)
+		}
+	}
+}
+
+//Tests the HTTP adapter
+func TestBotHandler(t *testing.T) {
+	query, _ := json.Marshal(botQuery{Board: testBotBoard, State: testBotTurn, Turn: 150, Scores: []int{10, 20}})
+	response := httptest.NewRecorder()
+	botHandler(response, httptest.NewRequest(http.MethodPost, "/turn", strings.NewReader(string(query))))
+	var moves botMoves
+	if err := json.NewDecoder(response.Body).Decode(&moves); err != nil || response.Code != http.StatusOK || len(moves.Moves) != 2 {
+		t.Fatal("Wrong answer:", response.Code, err, moves)
+	}
+	if turn != 150 || players[0].score != 10 || players[1].score != 20 {
+		t.Error("The turn and the scores of the query should be used", turn, players)
+	}
+	var testCases = []struct {
+		method, body string
+		code         int
+	}{
+		{http.MethodGet, "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "{", http.StatusBadRequest},
+		{http.MethodPost, `{"board": {"numPlayers": 2}}`, http.StatusBadRequest},
+	}
+	for i, testCase := range testCases {
+		response := httptest.NewRecorder()
+		botHandler(response, httptest.NewRequest(testCase.method, "/turn", strings.NewReader(testCase.body)))
+		if response.Code != testCase.code {
+			t.Error("Error in item", i, "Got", response.Code, "Expected", testCase.code)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+//Participating Game of Drones by CodinGame - Command line tools
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	EXIT_OK    = 0 //Exit code of commands that work
+	EXIT_ERROR = 1 //Exit code of commands that fail
+	EXIT_USAGE = 2 //Exit code of commands called with wrong arguments
+)
+
+//Tools that can be run from the command line instead of playing. Key = name of the command
+var commands = map[string]command{
+	"svg":      {"svg <replay> <turn> [<output.svg>]: draws a turn of a recorded game", commandSVG},
+	"html":     {"html <replay> [<output.html>]: generates a page that plays a recorded game", commandHTML},
+	"features": {"features <replays folder> <output folder> [csv|json]: writes the features of recorded games", commandFeatures},
+	"serve":    {"serve [<address>]: answers with the moves of the bot the states posted to http://<address>/turn", commandServe},
+	"train":    {"train <games> <seed> [<weights.txt> [<rules>]]: learns the evaluation weights playing against itself", commandTrain},
+	"play":     {"play [<rules>] [minimax] [evolution]: plays reading the standard input with the rules <width>,<height>,<zone radius>,<drone speed>,<turns>, deciding the attacks with a minimax search if minimax is given and planning the moves with an evolutionary planner if evolution is given", commandPlay},
+}
+
+var playOptions = map[string]*bool{ //Options of the play command that enable other planners. Key = name of the option
+	"minimax":   &minimaxAttacks,
+	"evolution": &evolutionPlanner,
+}
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* DATA TYPES BEGIN */
+
+//A command line tool
+type command struct {
+	usage string                  //How the command is used
+	run   func(args []string) int //Runs the command with the given arguments and returns the exit code
+}
+
+/* DATA TYPES END ******************************************************************** COMMANDS BEGIN */
+
+//Runs the command named by the first argument and returns the exit code
+func runCommand(args []string) int {
+	if c, exists := commands[args[0]]; exists {
+		code := c.run(args[1:])
+		if code == EXIT_USAGE {
+			fmt.Fprintln(os.Stderr, "Usage:", c.usage)
+		}
+		return code
+	}
+	fmt.Fprintln(os.Stderr, "Unknown command", args[0]+". Available commands:")
+	names := make([]string, 0, len(commands))
+	for name, _ := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintln(os.Stderr, "  "+commands[name].usage)
+	}
+	return EXIT_USAGE
+}
+
+//Draws a turn of a recorded game as an SVG image in the standard output or in the given file
+func commandSVG(args []string) int {
+	if len(args) < 2 || len(args) > 3 {
+		return EXIT_USAGE
+	}
+	rp, err := loadReplayFile(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error loading replay:", err)
+		return EXIT_ERROR
+	}
+	t, err := strconv.Atoi(args[1])
+	if err == nil {
+		err = restoreTurn(rp, t)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Wrong turn:", err)
+		return EXIT_ERROR
+	}
+	if len(args) == 2 {
+		fmt.Print(svgSnapshot(SVG_WIDTH))
+		return EXIT_OK
+	}
+	if err := writeSVG(args[2], SVG_WIDTH); err != nil {
+		fmt.Fprintln(os.Stderr, "Error writing image:", err)
+		return EXIT_ERROR
+	}
+	return EXIT_OK
+}
+
+//Plays reading the standard input with the official rules or the given ones. The rest of the arguments are options
+//(see playOptions)
+func commandPlay(args []string) int {
+	rulesGiven := false
+	for _, arg := range args {
+		if option, exists := playOptions[arg]; exists {
+			*option = true
+			continue
+		}
+		if rulesGiven {
+			return EXIT_USAGE
+		}
+		r, err := parseRules(arg)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error parsing rules:", err)
+			return EXIT_USAGE
+		}
+		rules, rulesGiven = r, true
+	}
+	playStandardInput()
+	return EXIT_OK
+}
+
+/* COMMANDS END */
@@ -0,0 +1,33 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//Tests the options of the play command
+func TestCommandPlayOptions(t *testing.T) {
+	defer func() { minimaxAttacks, evolutionPlanner, realExecution = false, false, false }()
+	if code := commandPlay([]string{"4000,1800,100,100,200", "4000,1800,100,100,200"}); code != EXIT_USAGE {
+		t.Error("Rules should be given once. Got", code)
+	}
+	if code := commandPlay([]string{"fast"}); code != EXIT_USAGE {
+		t.Error("Unknown options should be a usage error. Got", code)
+	}
+	f, err := os.Open(filepath.FromSlash(strings.Replace(FILE_TESTS_BASE+"minimax\\input.txt", "\\", "/", -1)))
+	if err != nil {
+		t.Fatal("Error opening input file:", err)
+	}
+	defer f.Close()
+	stdin := os.Stdin
+	os.Stdin = f
+	defer func() { os.Stdin = stdin }()
+	code := EXIT_ERROR
+	captureOutput(func() { code = commandPlay([]string{"minimax", "evolution"}) }, t)
+	if code != EXIT_OK || !minimaxAttacks || !evolutionPlanner {
+		t.Error("Both planners should be enabled. Got", code, minimaxAttacks, evolutionPlanner)
+	}
+}
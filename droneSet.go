@@ -0,0 +1,84 @@
+//Participating Game of Drones by CodinGame - Sets of drones
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/bits"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	MAX_DRONES_PER_PLAYER = 16 //Maximum number of drones of a player a droneSet can hold (the game uses up to 11)
+)
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* DATA TYPES BEGIN */
+
+//Set of drones of the same player. Bit i is set iff drone i belongs to the set
+type droneSet uint16
+
+/* DATA TYPES END ******************************************************************** DRONE SET BEGIN */
+
+//Returns a set with the given drones
+func newDroneSet(dIds ...int) (result droneSet) {
+	for _, dId := range dIds {
+		result.add(dId)
+	}
+	return result
+}
+
+//Adds a drone to the set
+func (s *droneSet) add(dId int) {
+	*s |= 1 << uint(dId)
+}
+
+//Removes a drone from the set
+func (s *droneSet) remove(dId int) {
+	*s &^= 1 << uint(dId)
+}
+
+//Returns true iff the drone belongs to the set
+func (s droneSet) contains(dId int) bool {
+	return s&(1<<uint(dId)) != 0
+}
+
+//Returns the drones that belong to any of both sets
+func (s droneSet) union(other droneSet) droneSet {
+	return s | other
+}
+
+//Returns the drones of the set that do not belong to the other one
+func (s droneSet) difference(other droneSet) droneSet {
+	return s &^ other
+}
+
+//Returns the number of drones in the set
+func (s droneSet) count() int {
+	return bits.OnesCount16(uint16(s))
+}
+
+//Returns the lowest ID in the set greater than the given one, or -1 if there is none. Drones are iterated in order with
+//  for dId := s.next(-1); dId >= 0; dId = s.next(dId)
+func (s droneSet) next(dId int) int {
+	rest := uint16(s) >> uint(dId+1)
+	if rest == 0 {
+		return -1
+	}
+	return dId + 1 + bits.TrailingZeros16(rest)
+}
+
+//Returns the IDs of the drones in the set, lowest first
+func (s droneSet) String() string {
+	var result bytes.Buffer
+	result.WriteString("{")
+	for dId := s.next(-1); dId >= 0; dId = s.next(dId) {
+		if result.Len() > 1 {
+			result.WriteString(" ")
+		}
+		result.WriteString(fmt.Sprint(dId))
+	}
+	result.WriteString("}")
+	return result.String()
+}
+
+/* DRONE SET END */
@@ -0,0 +1,92 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"testing"
+)
+
+const BENCHMARK_INPUT = FILE_TESTS_BASE + "benchmark\\input4x11x8.txt" //4 players, 11 drones, 8 zones
+
+//Tests the operations of droneSet
+func TestDroneSet(t *testing.T) {
+	s := newDroneSet(0, 3, 10)
+	if s.count() != 3 || !s.contains(0) || !s.contains(3) || !s.contains(10) || s.contains(1) {
+		t.Error("Wrong set:", s)
+	}
+	s.remove(3)
+	s.remove(4)
+	if s.count() != 2 || s.contains(3) {
+		t.Error("Wrong set after removing:", s)
+	}
+	other := newDroneSet(0, 1)
+	if result := s.union(other); result != newDroneSet(0, 1, 10) {
+		t.Error("Wrong union:", result)
+	}
+	if result := s.difference(other); result != newDroneSet(10) {
+		t.Error("Wrong difference:", result)
+	}
+	if result := s.String(); result != "{0 10}" {
+		t.Error("Wrong text:", result)
+	}
+}
+
+//Tests the ordered iteration over a droneSet
+func TestDroneSetNext(t *testing.T) {
+	var testCases = []struct {
+		in  droneSet
+		out []int
+	}{
+		{newDroneSet(), []int{}},
+		{newDroneSet(0), []int{0}},
+		{newDroneSet(5, 1, 3), []int{1, 3, 5}},
+		{newDroneSet(MAX_DRONES_PER_PLAYER - 1), []int{MAX_DRONES_PER_PLAYER - 1}},
+	}
+	for i, testCase := range testCases {
+		result := make([]int, 0)
+		for dId := testCase.in.next(-1); dId >= 0; dId = testCase.in.next(dId) {
+			result = append(result, dId)
+		}
+		if len(result) != len(testCase.out) {
+			t.Error("Error in item", i, "Got", result, "Expected", testCase.out)
+			continue
+		}
+		for j, _ := range result {
+			if result[j] != testCase.out[j] {
+				t.Error("Error in item", i, "Got", result, "Expected", testCase.out)
+			}
+		}
+	}
+}
+
+//Measures the computation of a whole turn
+func BenchmarkTurn(b *testing.B) {
+	setUpBenchmarkFromFile(BENCHMARK_INPUT, b)
+	for i := 0; i < b.N; i++ {
+		decideMoves()
+	}
+}
+
+//Measures method playerDronesNearZone
+func BenchmarkPlayerDronesNearZone(b *testing.B) {
+	setUpBenchmarkFromFile(BENCHMARK_INPUT, b)
+	for i := 0; i < b.N; i++ {
+		playerDronesNearZone(i%numPlayers, i%numZones, i%rules.maxDistance)
+	}
+}
+
+//Measures method calculateDonesAvailableDistances
+func BenchmarkCalculateDonesAvailableDistances(b *testing.B) {
+	setUpBenchmarkFromFile(BENCHMARK_INPUT, b)
+	for i := 0; i < b.N; i++ {
+		initializeTurnComputation()
+		calculateDonesAvailableDistances()
+	}
+}
+
+//Measures method bestAttackToZone
+func BenchmarkBestAttackToZone(b *testing.B) {
+	setUpBenchmarkFromFile(BENCHMARK_INPUT, b)
+	for i := 0; i < b.N; i++ {
+		bestAttackToZone(i % numZones)
+	}
+}
@@ -0,0 +1,144 @@
+//Participating Game of Drones by CodinGame - Evaluation of the status of the game
+package main
+
+import (
+	"fmt"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	EVAL_PROJECTION_TURNS = 5 //Number of turns the projected control of the zones and their vulnerability look ahead
+)
+
+var evaluationWeights = evalWeights{zones: 4.0, projection: 2.0, score: 1.0, finalLead: 0.05, spare: 0.5, vulnerability: -2.0} //How states are evaluated
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* DATA TYPES BEGIN */
+
+//What the status of the game looks like from the point of view of a player. Every feature compares the player with its
+//best enemy in that feature (the higher, the better for the player) but spare and vulnerability, that are its own ones
+type evalFeatures struct {
+	zones         float64 //Difference of owned zones
+	projection    float64 //Difference of zones each one would own, on average, during the next EVAL_PROJECTION_TURNS turns if every drone went to each zone
+	score         float64 //Difference of scores
+	finalLead     float64 //Difference of scores at the end of the game if the zones kept their owners
+	spare         float64 //Drones of the player not needed to keep its zones during the next EVAL_PROJECTION_TURNS turns
+	vulnerability float64 //Zones of the player where an enemy can bring more drones than the player in EVAL_PROJECTION_TURNS turns or less
+}
+
+//Weight of each feature in the evaluation of a state
+type evalWeights evalFeatures
+
+/* DATA TYPES END ******************************************************************** EVALUATION BEGIN */
+
+//Returns how good the state is for the player: the sum of its features multiplied by the weights
+func evaluateState(s simState, pId int, w evalWeights) float64 {
+	return stateFeatures(s, pId).value(w)
+}
+
+//Returns the features of the state from the point of view of the player
+func stateFeatures(s simState, pId int) (result evalFeatures) {
+	zoneCounts := s.zoneCounts()
+	projected := projectedZoneCounts(s)
+	remaining := maxInt(0, rules.MaxTurns-s.turn)
+	first := true
+	for other, _ := range s.scores {
+		if other == pId {
+			continue
+		}
+		zoneLead := float64(zoneCounts[pId] - zoneCounts[other])
+		projectionLead := projected[pId] - projected[other]
+		scoreLead := float64(s.scores[pId] - s.scores[other])
+		finalLead := scoreLead + zoneLead*float64(remaining)
+		if first {
+			result.zones, result.projection, result.score, result.finalLead = zoneLead, projectionLead, scoreLead, finalLead
+			first = false
+			continue
+		}
+		result.zones = minFloat(result.zones, zoneLead)
+		result.projection = minFloat(result.projection, projectionLead)
+		result.score = minFloat(result.score, scoreLead)
+		result.finalLead = minFloat(result.finalLead, finalLead)
+	}
+	need := 0
+	for zId, owner := range s.owners {
+		if owner != pId {
+			continue
+		}
+		for dist := 0; dist <= EVAL_PROJECTION_TURNS; dist += 1 {
+			if strongestSimChallenger(s, zId, pId, dist) > simDronesNearZone(s, pId, zId, dist) {
+				result.vulnerability++
+				break
+			}
+		}
+		need += strongestSimChallenger(s, zId, pId, EVAL_PROJECTION_TURNS)
+	}
+	result.spare = float64(maxInt(0, len(s.drones[pId])-need))
+	return result
+}
+
+//Returns the sum of the features multiplied by the weights
+func (f evalFeatures) value(w evalWeights) float64 {
+	return f.zones*w.zones + f.projection*w.projection + f.score*w.score + f.finalLead*w.finalLead +
+		f.spare*w.spare + f.vulnerability*w.vulnerability
+}
+
+//Returns the number of zones each player would own, on average, during the next EVAL_PROJECTION_TURNS turns if every
+//drone went straight to each zone: the owner of a zone changes when a player can bring more drones to it than the rest
+func projectedZoneCounts(s simState) []float64 {
+	result := make([]float64, len(s.scores))
+	for zId, owner := range s.owners {
+		for dist := 1; dist <= EVAL_PROJECTION_TURNS; dist += 1 {
+			best, bestCount, tie := -1, 0, false
+			for pId, _ := range s.drones {
+				if count := simDronesNearZone(s, pId, zId, dist); count > bestCount {
+					best, bestCount, tie = pId, count, false
+				} else if count == bestCount {
+					tie = true
+				}
+			}
+			if best >= 0 && !tie {
+				owner = best
+			}
+			if owner >= 0 && owner < len(result) {
+				result[owner] += 1.0 / EVAL_PROJECTION_TURNS
+			}
+		}
+	}
+	return result
+}
+
+//Returns the number of drones of the player that can be in the zone in the given number of turns
+func simDronesNearZone(s simState, pId, zId, dist int) (result int) {
+	for _, d := range s.drones[pId] {
+		if turnBasedDistance(d, zones[zId].pos) <= dist {
+			result++
+		}
+	}
+	return result
+}
+
+//Returns the highest number of drones any player but the given one can bring to the zone in the given number of turns
+func strongestSimChallenger(s simState, zId, pId, dist int) (result int) {
+	for other, _ := range s.drones {
+		if other != pId {
+			result = maxInt(result, simDronesNearZone(s, other, zId, dist))
+		}
+	}
+	return result
+}
+
+//Returns the lowest of both numbers
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+//Returns the features as text
+func (f evalFeatures) String() string {
+	return fmt.Sprintf("zones: %g projection: %g score: %g final lead: %g spare: %g vulnerability: %g",
+		f.zones, f.projection, f.score, f.finalLead, f.spare, f.vulnerability)
+}
+
+/* EVALUATION END */
@@ -0,0 +1,74 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+//Tests the features of a state where the enemy owns a zone and I can take the other one
+func TestStateFeatures(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	s := currentSimState()
+	f := stateFeatures(s, whoami)
+	remaining := float64(rules.MaxTurns - turn)
+	expected := evalFeatures{zones: -1, projection: -0.6, score: -1, finalLead: -1 - remaining, spare: 2, vulnerability: 0}
+	if math.Abs(f.projection-expected.projection) > 1e-9 {
+		t.Error("I should be projected to own zone 0 in the last 2 of 5 turns. Got", f)
+	}
+	f.projection = expected.projection
+	if f != expected {
+		t.Error("Wrong features. Got", f, "Expected", expected)
+	}
+	s.drones[whoami][0] = point{600, 500}
+	if f := stateFeatures(s, whoami); math.Abs(f.projection) > 1e-9 {
+		t.Error("I should be projected to own zone 0 from the next turn. Got", f)
+	}
+}
+
+//Tests that my zones are vulnerable when my drones cannot defend them in time
+func TestStateFeaturesVulnerability(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"maintainAirSuperiority\\inputOwned1Vs1.txt", t)
+	s := currentSimState()
+	if f := stateFeatures(s, whoami); f.vulnerability != 0 || f.spare != 0 {
+		t.Error("My zone can be defended with all my drones. Got", f)
+	}
+	s.drones[whoami][1], s.drones[whoami][2] = point{3000, 1500}, point{3000, 1500}
+	if f := stateFeatures(s, whoami); f.vulnerability != 1 {
+		t.Error("My zone should be vulnerable. Got", f)
+	}
+}
+
+//Tests that, in every two-player test input, what is good for me is equally bad for my enemy
+func TestStateFeaturesAntisymmetry(t *testing.T) {
+	for _, path := range gameInputs(t) {
+		setUpTestFromFile(path, t)
+		if numPlayers != 2 {
+			continue
+		}
+		s := currentSimState()
+		mine, theirs := stateFeatures(s, whoami), stateFeatures(s, 1-whoami)
+		if mine.zones != -theirs.zones || mine.score != -theirs.score || mine.finalLead != -theirs.finalLead ||
+			math.Abs(mine.projection+theirs.projection) > 1e-9 {
+			t.Error(path, "Features should be opposite. Mine:", mine, "Theirs:", theirs)
+		}
+	}
+}
+
+//Tests that the weights are applied to each feature
+func TestEvaluateState(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	s := currentSimState()
+	if result := evaluateState(s, whoami, evalWeights{score: 1}); result != -1 {
+		t.Error("Only the score should count. Got", result)
+	}
+	if result := evaluateState(s, whoami, evalWeights{zones: 2, spare: 1}); result != 0 {
+		t.Error("Zones and spare drones should compensate. Got", result)
+	}
+	before := evaluateState(s, whoami, evaluationWeights)
+	s.targets[whoami][0] = zones[0].pos
+	s.advance(5)
+	if after := evaluateState(s, whoami, evaluationWeights); after <= before {
+		t.Error("Taking zone 0 should improve the evaluation", before, after)
+	}
+}
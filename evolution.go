@@ -0,0 +1,267 @@
+//Participating Game of Drones by CodinGame - Rolling horizon evolutionary planner
+package main
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	EVOLUTION_HORIZON         = 8    //Number of turns each genome plans
+	EVOLUTION_POPULATION      = 16   //Number of genomes of each generation
+	EVOLUTION_ELITE           = 2    //Number of best genomes that pass unchanged to the next generation
+	EVOLUTION_TOURNAMENT      = 3    //Number of genomes that compete to be chosen as a parent
+	EVOLUTION_MUTATION        = 0.05 //Probability of each gene of changing after the crossover
+	EVOLUTION_MAX_GENERATIONS = 500  //Maximum number of generations per turn
+	EVOLUTION_MILLIS          = 40   //Milliseconds of the turn the planner can use
+	EVOLUTION_SEED            = 1    //Seed of the random numbers (plus the number of the turn)
+)
+
+var ( //evolution-related variables
+	evolutionPlanner bool                                  //True iff strategyEvolution plans the moves instead of the squads (see playOptions)
+	evolutionBudget  = EVOLUTION_MILLIS * time.Millisecond //Time of the turn the planner can use
+	lastBestGenome   genome                                //Best plan of the previous turn. nil in the first turn
+)
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* DATA TYPES BEGIN */
+
+//Plan of my drones: the zone each drone goes to (-1 to stay where it is) in each of the next turns. First index = turn
+type genome [][]int
+
+//Genome and how good it is
+type individual struct {
+	genes   genome  //The plan
+	fitness float64 //Evaluation of the simulated state at the end of the plan. The higher, the better
+}
+
+//Implements sort.Interface. Individuals with higher fitness go first
+type byFitness []individual
+
+//Necessary to implement sort.Interface
+func (is byFitness) Less(i, j int) bool {
+	return is[i].fitness > is[j].fitness
+}
+
+//Necessary to implement sort.Interface
+func (is byFitness) Swap(i, j int) {
+	is[i], is[j] = is[j], is[i]
+}
+
+//Necessary to implement sort.Interface
+func (is byFitness) Len() int {
+	return len(is)
+}
+
+//Environment where the genomes are evolved
+type evolution struct {
+	rng          *rand.Rand //Source of random numbers
+	base         simState   //Status of the game at the beginning of the plan
+	movable      droneSet   //My drones the plan can move
+	available    []int      //Available distance of each of my drones (see availableDistance)
+	enemyTargets [][]point  //Predicted destination of each enemy drone. Array index = player's ID
+}
+
+/* DATA TYPES END ******************************************************************** EVOLUTION BEGIN */
+
+//Calculates the movements for unasigned drones based on the following strategy:
+//- The plans of my drones for the next EVOLUTION_HORIZON turns are evolved (see evolvePlan)
+//- Each drone goes where the first turn of the best plan says
+func strategyEvolution() {
+	plan := evolvePlan(evolutionBudget, EVOLUTION_MAX_GENERATIONS)
+	for dId := 0; dId < numDronesPerplayer; dId += 1 {
+		if isAssigned(dId) {
+			continue
+		}
+		if zId := plan[0][dId]; zId >= 0 {
+			assignDestinationZone(dId, zId, "It is the plan of the evolution")
+		} else {
+			assignDestinationPointNoisy(dId, players[whoami].drones[dId], "The plan of the evolution is to wait")
+		}
+	}
+	lastBestGenome = plan
+}
+
+//Returns the best plan found evolving a population, seeded with the best plan of the previous turn shifted by one turn,
+//during the budget or the given number of generations
+func evolvePlan(budget time.Duration, maxGenerations int) genome {
+	deadline := time.Now().Add(budget)
+	ev := newEvolution()
+	population := make([]individual, 0, EVOLUTION_POPULATION)
+	if lastBestGenome != nil {
+		population = append(population, ev.individual(ev.shiftGenome(lastBestGenome)))
+	}
+	population = append(population, ev.individual(stayGenome()))
+	for len(population) < EVOLUTION_POPULATION {
+		population = append(population, ev.individual(ev.randomGenome()))
+	}
+	sort.Stable(byFitness(population))
+	generations := 0
+	for ; generations < maxGenerations && time.Now().Before(deadline); generations += 1 {
+		next := append(make([]individual, 0, EVOLUTION_POPULATION), population[:EVOLUTION_ELITE]...)
+		for len(next) < EVOLUTION_POPULATION {
+			child := ev.crossover(ev.tournament(population).genes, ev.tournament(population).genes)
+			ev.mutate(child)
+			next = append(next, ev.individual(child))
+		}
+		sort.Stable(byFitness(next))
+		population = next
+	}
+	trace("Evolution:", generations, "generations. Best fitness", population[0].fitness)
+	return population[0].genes
+}
+
+//Returns the environment of the current turn. Enemy drones are predicted to keep their last movement
+func newEvolution() *evolution {
+	ev := &evolution{rng: rand.New(rand.NewSource(int64(EVOLUTION_SEED + turn))), base: currentSimState(), enemyTargets: make([][]point, numPlayers),
+		available: make([]int, numDronesPerplayer)}
+	for dId := 0; dId < numDronesPerplayer; dId += 1 {
+		if !isAssigned(dId) {
+			ev.movable.add(dId)
+		}
+		ev.available[dId] = availableDistance(dId)
+	}
+	for pId, p := range players {
+		ev.enemyTargets[pId] = append([]point(nil), p.drones...)
+		if pId == whoami || previousDrones == nil {
+			continue
+		}
+		for dId, d := range p.drones {
+			prev := previousDrones[pId][dId]
+			ev.enemyTargets[pId][dId] = clampToBoard(point{d.x + (d.x-prev.x)*EVOLUTION_HORIZON, d.y + (d.y-prev.y)*EVOLUTION_HORIZON})
+		}
+	}
+	return ev
+}
+
+//Returns the genome with its fitness: the value of the state after simulating its turns
+func (ev *evolution) individual(g genome) individual {
+	s := ev.base.clone()
+	for pId, _ := range s.targets {
+		if pId != whoami {
+			copy(s.targets[pId], ev.enemyTargets[pId])
+		}
+	}
+	for _, zIds := range g {
+		for dId, zId := range zIds {
+			if !ev.movable.contains(dId) {
+				continue
+			}
+			if zId >= 0 {
+				s.targets[whoami][dId] = zones[zId].pos
+			} else {
+				s.targets[whoami][dId] = s.drones[whoami][dId]
+			}
+		}
+		s.advance(1)
+	}
+	return individual{g, evaluateState(s, whoami, evaluationWeights)}
+}
+
+//Returns a genome with random genes
+func (ev *evolution) randomGenome() genome {
+	result := newGenome()
+	for t, _ := range result {
+		for dId, _ := range result[t] {
+			result[t][dId] = ev.randomGene(dId)
+		}
+	}
+	return result
+}
+
+//Returns a random zone for the drone, or -1. Zones the drone cannot reach within its available distance become -1
+func (ev *evolution) randomGene(dId int) int {
+	if zId := ev.rng.Intn(numZones+1) - 1; zId >= 0 && ev.reachable(dId, zId) {
+		return zId
+	}
+	return -1
+}
+
+//Returns true iff the drone can go to the zone without going farther than its available distance
+func (ev *evolution) reachable(dId, zId int) bool {
+	return turnBasedDistance(ev.base.drones[whoami][dId], zones[zId].pos) <= ev.available[dId]
+}
+
+//Returns the best of EVOLUTION_TOURNAMENT random individuals of the population
+func (ev *evolution) tournament(population []individual) individual {
+	best := population[ev.rng.Intn(len(population))]
+	for i := 1; i < EVOLUTION_TOURNAMENT; i += 1 {
+		if candidate := population[ev.rng.Intn(len(population))]; candidate.fitness > best.fitness {
+			best = candidate
+		}
+	}
+	return best
+}
+
+//Returns a genome that takes the plan of each drone from one of the parents at random
+func (ev *evolution) crossover(a, b genome) genome {
+	result := newGenome()
+	for dId := 0; dId < numDronesPerplayer; dId += 1 {
+		parent := a
+		if ev.rng.Intn(2) == 1 {
+			parent = b
+		}
+		for t, _ := range result {
+			result[t][dId] = parent[t][dId]
+		}
+	}
+	return result
+}
+
+//Changes each gene with probability EVOLUTION_MUTATION (see randomGene). A mutated gene keeps its new zone for the rest
+//of the plan
+func (ev *evolution) mutate(g genome) {
+	for t, _ := range g {
+		for dId, _ := range g[t] {
+			if ev.rng.Float64() < EVOLUTION_MUTATION {
+				zId := ev.randomGene(dId)
+				for rest := t; rest < len(g); rest += 1 {
+					g[rest][dId] = zId
+				}
+			}
+		}
+	}
+}
+
+//Returns an empty genome
+func newGenome() genome {
+	result := make(genome, EVOLUTION_HORIZON)
+	for t, _ := range result {
+		result[t] = make([]int, numDronesPerplayer)
+	}
+	return result
+}
+
+//Returns a genome where every drone stays where it is
+func stayGenome() genome {
+	result := newGenome()
+	for t, _ := range result {
+		for dId, _ := range result[t] {
+			result[t][dId] = -1
+		}
+	}
+	return result
+}
+
+//Returns the genome one turn later: without its first turn and repeating its last one. Zones the drones cannot reach
+//now within their available distance become -1
+func (ev *evolution) shiftGenome(g genome) genome {
+	result := newGenome()
+	for t, _ := range result {
+		copy(result[t], g[minInt(t+1, len(g)-1)])
+		for dId, zId := range result[t] {
+			if zId >= 0 && !ev.reachable(dId, zId) {
+				result[t][dId] = -1
+			}
+		}
+	}
+	return result
+}
+
+//Returns the nearest point of the board
+func clampToBoard(p point) point {
+	return point{minInt(maxInt(p.x, 0), rules.Width-1), minInt(maxInt(p.y, 0), rules.Height-1)}
+}
+
+/* EVOLUTION END */
@@ -0,0 +1,128 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+//Tests method shiftGenome
+func TestShiftGenome(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	g := newGenome()
+	for step, _ := range g {
+		g[step][0] = step%(numZones+1) - 1
+	}
+	ev := newEvolution()
+	ev.available[0] = rules.maxDistance //Every zone is reachable
+	shifted := ev.shiftGenome(g)
+	for step, _ := range shifted {
+		if expected := minInt(step+1, EVOLUTION_HORIZON-1)%(numZones+1) - 1; shifted[step][0] != expected {
+			t.Error("Wrong gene in step", step, "Got", shifted[step][0], "Expected", expected)
+		}
+	}
+	if g[0][0] != -1 {
+		t.Error("The original genome should not change")
+	}
+}
+
+//Tests that crossover and mutation only produce valid genes taken from the parents or the zones
+func TestGeneticOperators(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	ev := newEvolution()
+	a, b := ev.randomGenome(), stayGenome()
+	child := ev.crossover(a, b)
+	for dId := 0; dId < numDronesPerplayer; dId += 1 {
+		fromA, fromB := true, true
+		for step, _ := range child {
+			fromA = fromA && child[step][dId] == a[step][dId]
+			fromB = fromB && child[step][dId] == b[step][dId]
+		}
+		if !fromA && !fromB {
+			t.Error("The plan of drone", dId, "should come from a parent")
+		}
+	}
+	for i := 0; i < 100; i += 1 {
+		ev.mutate(child)
+	}
+	for step, _ := range child {
+		for _, zId := range child[step] {
+			if zId < -1 || zId >= numZones {
+				t.Error("Wrong gene:", zId)
+			}
+		}
+	}
+}
+
+//Tests that the evolution finds a plan better than staying, that takes the unguarded zone
+func TestEvolvePlan(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	calculateDonesAvailableDistances()
+	plan := evolvePlan(time.Minute, 50)
+	ev := newEvolution()
+	if ev.individual(plan).fitness <= ev.individual(stayGenome()).fitness {
+		t.Error("The plan should be better than staying", plan)
+	}
+	if plan[0][0] != 0 && plan[0][1] != 0 {
+		t.Error("Some drone should go to zone 0 in the first step", plan[0])
+	}
+	if again := evolvePlan(time.Minute, 50); again[0][0] != plan[0][0] || again[0][1] != plan[0][1] {
+		t.Error("The evolution should be deterministic", plan[0], again[0])
+	}
+}
+
+//Tests that strategyEvolution gives every drone the destination of the first step of its plan
+func TestStrategyEvolution(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	calculateDonesAvailableDistances()
+	strategyEvolution()
+	if numAssignedDrones() != numDronesPerplayer || lastBestGenome == nil {
+		t.Fatal("Every drone should have a destination", nextMove)
+	}
+	for dId, zId := range lastBestGenome[0] {
+		expected := players[whoami].drones[dId]
+		if zId >= 0 {
+			expected = zones[zId].pos
+		}
+		if nextMove[dId] != expected {
+			t.Error("Drone", dId, "should go to", expected, "Got", nextMove[dId])
+		}
+	}
+}
+
+//Tests that the evolutionary planner plans the moves when it is enabled
+func TestEvolutionPlannerEnabled(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	evolutionPlanner = true
+	defer func() { evolutionPlanner = false }()
+	decideMoves()
+	planned := false
+	for _, note := range turnNotes {
+		planned = planned || strings.Contains(note, "plan of the evolution")
+	}
+	if !planned {
+		t.Error("The moves should be planned by the evolution", turnNotes)
+	}
+}
+
+//Tests that the plans never send a drone farther than its available distance
+func TestEvolutionAvailability(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	availability.drones[0] = 2 //Drone 0 cannot reach any zone
+	ev := newEvolution()
+	g := ev.randomGenome()
+	for i := 0; i < 100; i += 1 {
+		ev.mutate(g)
+	}
+	shifted := ev.shiftGenome(newGenome()) //Every drone to zone 0
+	plan := evolvePlan(time.Minute, 50)
+	for step := 0; step < EVOLUTION_HORIZON; step += 1 {
+		if g[step][0] != -1 || shifted[step][0] != -1 || plan[step][0] != -1 {
+			t.Error("Drone 0 should stay in step", step, "Got", g[step][0], shifted[step][0], plan[step][0])
+		}
+	}
+	if shifted[0][1] != 0 {
+		t.Error("Drone 1 can still go to zone 0. Got", shifted[0][1])
+	}
+}
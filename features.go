@@ -0,0 +1,223 @@
+//Participating Game of Drones by CodinGame - Feature extraction for machine learning experiments
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	FEATURE_TURNS = 10 //Drone counts of each zone are extracted for 0, 1, ... FEATURE_TURNS turns
+)
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* DATA TYPES BEGIN */
+
+//Feature vectors of the same kind extracted from several turns
+type featureTable struct {
+	Names []string     `json:"names"` //Name of each feature
+	Rows  []featureRow `json:"rows"`  //One vector per turn or per (drone, zone) and turn
+}
+
+//Feature vector of a turn of a game
+type featureRow struct {
+	Game   string    `json:"game"`   //Name of the recorded game
+	Turn   int       `json:"turn"`   //Number of the turn
+	Values []float64 `json:"values"` //Value of each feature, in the order of the names of the table
+}
+
+/* DATA TYPES END ******************************************************************** FEATURE EXTRACTION BEGIN */
+
+//Returns the names of the features of a turn
+func turnFeatureNames() []string {
+	result := []string{"turn", "numPlayers", "numDrones", "numZones", "myScore", "bestEnemyScore", "myZones", "enemyZones",
+		"unreclaimedZones", "mySpareDrones", "safelyMine", "safelyTheirs", "contested", "panics"}
+	for _, name := range evalFeatureNames {
+		result = append(result, "eval_"+name)
+	}
+	return result
+}
+
+//Returns the features of the current turn, in the order of turnFeatureNames. The frontier must be calculated. The
+//panics are the ones reported in the notes of the turn (see safeRun)
+func turnFeatures() []float64 {
+	bestEnemyScore, myZones, enemyZones, unreclaimed := 0, 0, 0, 0
+	for pId, p := range players {
+		if pId != whoami {
+			bestEnemyScore = maxInt(bestEnemyScore, p.score)
+		}
+	}
+	for _, z := range zones {
+		switch z.owner {
+		case whoami:
+			myZones++
+		case UNRECLAIMED:
+			unreclaimed++
+		default:
+			enemyZones++
+		}
+	}
+	statuses := make([]int, CONTESTED+1)
+	for _, status := range frontier {
+		statuses[status]++
+	}
+	result := []float64{float64(turn), float64(numPlayers), float64(numDronesPerplayer), float64(numZones),
+		float64(players[whoami].score), float64(bestEnemyScore), float64(myZones), float64(enemyZones), float64(unreclaimed),
+		float64(spareDrones[whoami]), float64(statuses[SAFELY_MINE]), float64(statuses[SAFELY_THEIRS]), float64(statuses[CONTESTED]),
+		float64(notePanics(turnNotes))}
+	return append(result, stateFeatures(currentSimState(), whoami).vector()...)
+}
+
+//Returns the names of the features of a drone of mine and a zone
+func pairFeatureNames() []string {
+	result := []string{"drone", "zone", "distance", "mine", "enemy", "unreclaimed", "availability", "zoneValue", "contested"}
+	for dist := 0; dist <= FEATURE_TURNS; dist += 1 {
+		result = append(result, fmt.Sprint("myDrones", dist))
+	}
+	for dist := 0; dist <= FEATURE_TURNS; dist += 1 {
+		result = append(result, fmt.Sprint("enemies", dist))
+	}
+	return append(result, "moveToZone")
+}
+
+//Returns the features of my drone and the zone in the current turn, in the order of pairFeatureNames. move is where
+//the drone was sent. The availability of the drones and the frontier must be calculated
+func pairFeatures(dId, zId int, move point) []float64 {
+	owner := zones[zId].owner
+	result := []float64{float64(dId), float64(zId), float64(distances[whoami][dId][zId]), boolFeature(owner == whoami),
+		boolFeature(owner != whoami && owner != UNRECLAIMED), boolFeature(owner == UNRECLAIMED), float64(availableDistance(dId)),
+		zoneValue(zId), boolFeature(frontier[zId] == CONTESTED)}
+	for dist := 0; dist <= FEATURE_TURNS; dist += 1 {
+		result = append(result, float64(playerDronesNearZoneCount(whoami, zId, dist)))
+	}
+	for dist := 0; dist <= FEATURE_TURNS; dist += 1 {
+		result = append(result, float64(maxEnemiesNearZone(zId, dist)))
+	}
+	return append(result, boolFeature(move == zones[zId].pos))
+}
+
+//Returns 1 for true and 0 for false
+func boolFeature(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+//Adds to the tables the features of every turn of the recorded game, with the moves I chose
+func extractReplayFeatures(game string, rp replay, turns, pairs *featureTable) error {
+	for t := 1; t <= len(rp.turns); t += 1 {
+		if err := restoreTurn(rp, t); err != nil {
+			return err
+		}
+		if t == 1 {
+			initializeBoard()
+			previousDrones = nil
+		} else {
+			previousDrones = rp.turns[t-2].drones
+		}
+		initializeTurnComputation()
+		calculateDonesAvailableDistances()
+		turns.Rows = append(turns.Rows, featureRow{game, t, turnFeatures()})
+		moves := rp.turns[t-1].moves
+		for dId := 0; dId < numDronesPerplayer; dId += 1 {
+			for zId := 0; zId < numZones; zId += 1 {
+				pairs.Rows = append(pairs.Rows, featureRow{game, t, pairFeatures(dId, zId, moves[dId])})
+			}
+		}
+	}
+	return nil
+}
+
+/* FEATURE EXTRACTION END ******************************************************************** OUTPUT BEGIN */
+
+//Writes the table as CSV: a header with the names and a line per row, both starting with the game and the turn
+func (ft featureTable) writeCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(append([]string{"game", "turn"}, ft.Names...)); err != nil {
+		return err
+	}
+	for _, row := range ft.Rows {
+		record := []string{row.Game, strconv.Itoa(row.Turn)}
+		for _, v := range row.Values {
+			record = append(record, strconv.FormatFloat(v, 'g', -1, 64))
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+//Writes the table as JSON
+func (ft featureTable) writeJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(ft)
+}
+
+//Writes the features of every recorded game in a folder into turns.<format> and pairs.<format> (format is csv, the
+//default, or json) in the output folder
+func commandFeatures(args []string) int {
+	if len(args) < 2 || len(args) > 3 {
+		return EXIT_USAGE
+	}
+	format := "csv"
+	if len(args) == 3 {
+		format = args[2]
+	}
+	if format != "csv" && format != "json" {
+		return EXIT_USAGE
+	}
+	entries, err := os.ReadDir(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error reading folder:", err)
+		return EXIT_ERROR
+	}
+	turns, pairs := featureTable{Names: turnFeatureNames()}, featureTable{Names: pairFeatureNames()}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		rp, err := loadReplayFile(filepath.Join(args[0], entry.Name()))
+		if err == nil {
+			err = extractReplayFeatures(entry.Name(), rp, &turns, &pairs)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Skipping", entry.Name()+":", err)
+		}
+	}
+	err = writeFeatureFile(filepath.Join(args[1], "turns."+format), turns, format)
+	if err == nil {
+		err = writeFeatureFile(filepath.Join(args[1], "pairs."+format), pairs, format)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error writing features:", err)
+		return EXIT_ERROR
+	}
+	return EXIT_OK
+}
+
+//Writes the table into a file in the given format
+func writeFeatureFile(path string, ft featureTable, format string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if format == "json" {
+		err = ft.writeJSON(f)
+	} else {
+		err = ft.writeCSV(f)
+	}
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+/* OUTPUT END */
@@ -0,0 +1,130 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//Tests that the feature vectors have the same length as their names in every test input
+func TestFeatureLengths(t *testing.T) {
+	for _, path := range gameInputs(t) {
+		setUpTestFromFile(path, t)
+		calculateDonesAvailableDistances()
+		if len(turnFeatures()) != len(turnFeatureNames()) {
+			t.Error(path, "Wrong number of turn features:", len(turnFeatures()), "Expected", len(turnFeatureNames()))
+		}
+		if len(pairFeatures(0, 0, point{})) != len(pairFeatureNames()) {
+			t.Error(path, "Wrong number of pair features:", len(pairFeatures(0, 0, point{})), "Expected", len(pairFeatureNames()))
+		}
+	}
+}
+
+//Tests the features of a drone and a zone
+func TestPairFeatures(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	calculateDonesAvailableDistances()
+	features := pairFeatures(0, 0, zones[0].pos)
+	names := pairFeatureNames()
+	expected := map[string]float64{"drone": 0, "zone": 0, "distance": 4, "mine": 0, "enemy": 0, "unreclaimed": 1,
+		"availability": float64(rules.maxDistance), "contested": 1, "myDrones3": 0, "myDrones4": 1, "myDrones5": 2, "enemies10": 0, "moveToZone": 1}
+	for i, name := range names {
+		if value, exists := expected[name]; exists && features[i] != value {
+			t.Error("Wrong feature", name, "Got", features[i], "Expected", value)
+		}
+	}
+	if features := pairFeatures(0, 1, zones[0].pos); features[len(features)-1] != 0 || features[4] != 1 {
+		t.Error("Zone 1 is the enemy's and drone 0 does not go there", features)
+	}
+}
+
+//Tests that every turn of a recorded game, and every drone and zone in it, gets a feature vector
+func TestExtractReplayFeatures(t *testing.T) {
+	rp := loadTestReplay(t)
+	turns, pairs := featureTable{Names: turnFeatureNames()}, featureTable{Names: pairFeatureNames()}
+	if err := extractReplayFeatures("game", rp, &turns, &pairs); err != nil {
+		t.Fatal("Error extracting features:", err)
+	}
+	if len(turns.Rows) != len(rp.turns) || len(pairs.Rows) != len(rp.turns)*rp.numDrones*len(rp.zones) {
+		t.Fatal("Wrong number of rows:", len(turns.Rows), len(pairs.Rows))
+	}
+	for i, row := range turns.Rows {
+		if row.Turn != i+1 || row.Values[0] != float64(i+1) {
+			t.Error("Wrong turn in row", i, row)
+		}
+	}
+	var output strings.Builder
+	if err := turns.writeCSV(&output); err != nil {
+		t.Fatal("Error writing CSV:", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != len(turns.Rows)+1 || !strings.HasPrefix(lines[0], "game,turn,turn,numPlayers") ||
+		!strings.HasPrefix(lines[1], "game,1,1,2,") {
+		t.Error("Wrong CSV:", lines)
+	}
+}
+
+//Tests that the names of the games are escaped in the CSV
+func TestWriteCSVEscaping(t *testing.T) {
+	ft := featureTable{[]string{"value"}, []featureRow{{"a,\"b\".txt", 1, []float64{0.5}}}}
+	var output strings.Builder
+	if err := ft.writeCSV(&output); err != nil {
+		t.Fatal("Error writing CSV:", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(output.String())).ReadAll()
+	if err != nil || len(records) != 2 || len(records[1]) != 3 || records[1][0] != "a,\"b\".txt" || records[1][2] != "0.5" {
+		t.Error("Wrong CSV:", output.String(), err)
+	}
+}
+
+//Tests that malformed recorded games are skipped by the features command
+func TestCommandFeaturesMalformed(t *testing.T) {
+	folder, output := t.TempDir(), t.TempDir()
+	if err := os.WriteFile(filepath.Join(folder, "bad.txt"), []byte("2 0 2 1\n500 500\n9\n"), 0644); err != nil {
+		t.Fatal("Error writing replay:", err)
+	}
+	if code := commandFeatures([]string{folder, output}); code != EXIT_OK {
+		t.Error("Malformed games should be skipped. Got", code)
+	}
+}
+
+//Tests that the panics of each recorded turn are a feature of the turn
+func TestExtractReplayFeaturesPanics(t *testing.T) {
+	rp := loadTestReplay(t)
+	rp.turns[1].notes = append(rp.turns[1].notes, PANIC_NOTE_PREFIX+" 1 in faulty: boom")
+	turns, pairs := featureTable{Names: turnFeatureNames()}, featureTable{Names: pairFeatureNames()}
+	if err := extractReplayFeatures("game", rp, &turns, &pairs); err != nil {
+		t.Fatal("Error extracting features:", err)
+	}
+	for i, name := range turns.Names {
+		if name == "panics" && (turns.Rows[0].Values[i] != 0 || turns.Rows[1].Values[i] != 1) {
+			t.Error("Wrong panics:", turns.Rows[0].Values[i], turns.Rows[1].Values[i])
+		}
+	}
+}
+
+//Tests that the features command writes both tables of a folder of recorded games
+func TestCommandFeatures(t *testing.T) {
+	if code := commandFeatures([]string{"a", "b", "xml"}); code != EXIT_USAGE {
+		t.Error("Unknown formats should be a usage error. Got", code)
+	}
+	folder := filepath.Dir(filepath.FromSlash(strings.Replace(REPLAY_TEST_FILE, "\\", "/", -1)))
+	output := t.TempDir()
+	if code := commandFeatures([]string{folder, output, "json"}); code != EXIT_OK {
+		t.Fatal("Wrong exit code:", code)
+	}
+	for _, name := range []string{"turns.json", "pairs.json"} {
+		content, err := os.ReadFile(filepath.Join(output, name))
+		var ft featureTable
+		if err == nil {
+			err = json.Unmarshal(content, &ft)
+		}
+		if err != nil || len(ft.Rows) == 0 || len(ft.Rows[0].Values) != len(ft.Names) || ft.Rows[0].Game != "game.txt" {
+			t.Error("Wrong", name, err)
+		}
+	}
+}
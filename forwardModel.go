@@ -0,0 +1,106 @@
+//Participating Game of Drones by CodinGame - Fast forward model of the game
+package main
+
+/* ********************************************************************************************** DATA TYPES BEGIN */
+
+//Status of a simulated game
+type simState struct {
+	turn    int       //Number of the last simulated turn
+	owners  []int     //Owner of each zone
+	scores  []int     //Score of each player
+	drones  [][]point //Position of each drone of each player
+	targets [][]point //Destination of each drone of each player. Drones stay where they are until they get one
+}
+
+/* DATA TYPES END ******************************************************************** FORWARD MODEL BEGIN */
+
+//Returns the current status of the game as a simulated one where every drone stays where it is
+func currentSimState() simState {
+	s := simState{turn, make([]int, numZones), make([]int, numPlayers), make([][]point, numPlayers), make([][]point, numPlayers)}
+	for zId, z := range zones {
+		s.owners[zId] = z.owner
+	}
+	for pId, p := range players {
+		s.scores[pId] = p.score
+		s.drones[pId] = append([]point(nil), p.drones...)
+		s.targets[pId] = append([]point(nil), p.drones...)
+	}
+	return s
+}
+
+//Returns a copy of the state that can be modified without changing the original one
+func (s simState) clone() simState {
+	result := simState{s.turn, append([]int(nil), s.owners...), append([]int(nil), s.scores...), make([][]point, len(s.drones)), make([][]point, len(s.targets))}
+	for pId, _ := range s.drones {
+		result.drones[pId] = append([]point(nil), s.drones[pId]...)
+		result.targets[pId] = append([]point(nil), s.targets[pId]...)
+	}
+	return result
+}
+
+//Simulates a turn: every drone moves towards its target, the zones change their owners and the players score
+func (s *simState) step() {
+	for pId, ds := range s.drones {
+		for dId, d := range ds {
+			ds[dId] = moveTowards(d, s.targets[pId][dId])
+		}
+	}
+	counts := make([]int, len(s.drones))
+	for zId, z := range zones {
+		for pId, ds := range s.drones {
+			counts[pId] = 0
+			for _, d := range ds {
+				if euclideanDistance(d, z.pos) <= rules.ZoneRadius {
+					counts[pId]++
+				}
+			}
+		}
+		best, bestCount, tie := -1, 0, false
+		for pId, count := range counts {
+			if count > bestCount {
+				best, bestCount, tie = pId, count, false
+			} else if count == bestCount {
+				tie = true
+			}
+		}
+		if best >= 0 && !tie {
+			s.owners[zId] = best
+		}
+	}
+	for _, owner := range s.owners {
+		if owner >= 0 && owner < len(s.scores) {
+			s.scores[owner]++
+		}
+	}
+	s.turn++
+}
+
+//Simulates the given number of turns, or less if the game ends before
+func (s *simState) advance(numTurns int) {
+	for i := 0; i < numTurns && s.turn < rules.MaxTurns; i += 1 {
+		s.step()
+	}
+}
+
+//Returns the number of zones each player owns
+func (s simState) zoneCounts() []int {
+	result := make([]int, len(s.scores))
+	for _, owner := range s.owners {
+		if owner >= 0 && owner < len(result) {
+			result[owner]++
+		}
+	}
+	return result
+}
+
+//Returns where a drone in from going to to is after a turn
+func moveTowards(from, to point) point {
+	dist := euclideanDistance(from, to)
+	if dist <= rules.DroneSpeed {
+		return to
+	}
+	ratio := rules.DroneSpeed / dist
+	return point{from.x + int(float64(to.x-from.x)*ratio), from.y + int(float64(to.y-from.y)*ratio)}
+}
+
+/* FORWARD MODEL END */
@@ -0,0 +1,57 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"testing"
+)
+
+//Tests method moveTowards
+func TestMoveTowards(t *testing.T) {
+	var testCases = []struct {
+		from, to point
+		out      point
+	}{
+		{point{0, 0}, point{300, 400}, point{60, 80}},
+		{point{300, 400}, point{0, 0}, point{240, 320}},
+		{point{0, 0}, point{60, 80}, point{60, 80}}, //it arrives
+		{point{10, 10}, point{10, 10}, point{10, 10}},
+	}
+	for i, testCase := range testCases {
+		if result := moveTowards(testCase.from, testCase.to); testCase.out != result {
+			t.Error("Error in item", i, "Got", result, "Expected", testCase.out, "Case:", testCase)
+		}
+	}
+}
+
+//Tests that the simulated zones change their owners and the players score as in the game
+func TestSimStateStep(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	s := currentSimState()
+	s.targets[whoami][0] = zones[0].pos
+	original := s.clone()
+	s.advance(5)
+	if s.turn != turn+5 || s.drones[whoami][0] != zones[0].pos {
+		t.Error("Drone 0 should have reached zone 0 in 5 turns", s.turn, s.drones[whoami][0])
+	}
+	if s.owners[0] != whoami || s.owners[1] != 1 {
+		t.Error("Wrong owners:", s.owners)
+	}
+	if s.scores[whoami] != players[whoami].score+2 || s.scores[1] != players[1].score+5 {
+		t.Error("Wrong scores:", s.scores)
+	}
+	if original.owners[0] != UNRECLAIMED || original.drones[whoami][0] != players[whoami].drones[0] {
+		t.Error("The clone should not change")
+	}
+}
+
+//Tests that a tie keeps the owner of the zone
+func TestSimStateTie(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	s := currentSimState()
+	s.drones[whoami][0] = zones[1].pos
+	s.targets[whoami][0] = zones[1].pos
+	s.step()
+	if s.owners[1] != 1 {
+		t.Error("The owner should keep the zone in case of tie. Got", s.owners[1])
+	}
+}
@@ -0,0 +1,128 @@
+//Participating Game of Drones by CodinGame - Arrival races and contested frontier
+package main
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	FRONTIER_TURNS = 10 //Number of turns into the future the frontier analysis considers
+)
+
+const ( //Status of a zone in the frontier analysis
+	SAFELY_MINE   = iota //I own it and no enemy can outnumber my drones there
+	SAFELY_THEIRS        //An enemy owns it and I cannot outnumber its drones there
+	CONTESTED            //Anything else
+)
+
+var ( //frontier-related variables
+	earliestArrivals [][][]int //For each player, for each zone, turns needed to put 1, 2, ... all its drones in it
+	frontier         []int     //Status of each zone. Array index = zone's ID
+	spareDrones      []int     //Drones of each player not needed to keep its safe zones. Array index = player's ID
+)
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* FRONTIER BEGIN */
+
+//Calculates the earliest arrival tables and the frontier analysis of the current turn
+func calculateFrontier() {
+	calculateEarliestArrivals()
+	frontier = make([]int, numZones)
+	spareDrones = make([]int, numPlayers)
+	for pId, _ := range players {
+		spareDrones[pId] = numDronesPerplayer
+	}
+	for zId, z := range zones {
+		frontier[zId] = zoneFrontierStatus(zId)
+		if frontier[zId] == SAFELY_MINE || frontier[zId] == SAFELY_THEIRS {
+			spareDrones[z.owner] -= dronesNeededToKeep(zId)
+		}
+	}
+	for pId, _ := range players {
+		spareDrones[pId] = maxInt(0, spareDrones[pId])
+	}
+	trace("Frontier", frontier, "Spare drones", spareDrones)
+}
+
+//Calculates, for each player and zone, the turns needed to put 1, 2, ... all its drones in the zone (see arrivals)
+func calculateEarliestArrivals() {
+	for pId, _ := range players {
+		for zId, _ := range zones {
+			counts, times := arrivals[pId][zId], earliestArrivals[pId][zId]
+			dist := 0
+			for k, _ := range times {
+				for dist <= rules.maxDistance && counts[dist] <= k {
+					dist++
+				}
+				times[k] = dist
+			}
+		}
+	}
+}
+
+//Returns the status of the zone: it is safe for its owner iff, at every turn up to FRONTIER_TURNS, no other player can
+//bring to it more drones than the owner
+func zoneFrontierStatus(zId int) int {
+	owner := zones[zId].owner
+	if owner == UNRECLAIMED {
+		return CONTESTED
+	}
+	for dist := 0; dist <= FRONTIER_TURNS; dist += 1 {
+		if strongestChallenger(zId, dist) > playerDronesNearZoneCount(owner, zId, dist) {
+			return CONTESTED
+		}
+	}
+	if owner == whoami {
+		return SAFELY_MINE
+	}
+	return SAFELY_THEIRS
+}
+
+//Returns the highest number of drones a player that does not own the zone can bring to it in the given number of turns
+func strongestChallenger(zId, dist int) (result int) {
+	for pId, _ := range players {
+		if pId != zones[zId].owner {
+			result = maxInt(result, playerDronesNearZoneCount(pId, zId, dist))
+		}
+	}
+	return result
+}
+
+//Returns the number of drones the owner of the zone needs to keep it during the next FRONTIER_TURNS turns
+func dronesNeededToKeep(zId int) int {
+	return strongestChallenger(zId, FRONTIER_TURNS)
+}
+
+//Returns the turns the player needs to put the given number of drones in the zone (rules.maxDistance+1 if it cannot)
+func earliestArrival(pId, zId, numDrones int) int {
+	if numDrones <= 0 {
+		return 0
+	}
+	if numDrones > len(earliestArrivals[pId][zId]) {
+		return rules.maxDistance + 1
+	}
+	return earliestArrivals[pId][zId][numDrones-1]
+}
+
+//Returns the turns the fastest enemy of mine needs to put the given number of drones in the zone
+func enemyEarliestArrival(zId, numDrones int) int {
+	if numDrones <= 0 {
+		return 0
+	}
+	result := rules.maxDistance + 1
+	for pId, _ := range players {
+		if pId != whoami {
+			result = minInt(result, earliestArrival(pId, zId, numDrones))
+		}
+	}
+	return result
+}
+
+//Returns the name of the given frontier status
+func frontierName(status int) string {
+	switch status {
+	case SAFELY_MINE:
+		return "mine"
+	case SAFELY_THEIRS:
+		return "theirs"
+	}
+	return "contested"
+}
+
+/* FRONTIER END */
@@ -0,0 +1,90 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"testing"
+)
+
+//Tests method calculateEarliestArrivals
+func TestEarliestArrivals(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"frontier\\input.txt", t)
+	var testCases = []struct {
+		pId, zId, numDrones int
+		out                 int
+	}{
+		{0, 0, 0, 0},
+		{0, 0, 3, 0},
+		{1, 0, 1, 3}, //400 units away, but it only needs to enter the zone
+		{1, 0, 2, distances[1][1][0]},
+		{1, 1, 2, 0},
+		{1, 1, 3, distances[1][0][1]},
+		{1, 0, 4, rules.maxDistance + 1}, //more drones than the player has
+	}
+	for i, testCase := range testCases {
+		if result := earliestArrival(testCase.pId, testCase.zId, testCase.numDrones); testCase.out != result {
+			t.Error("Error in item", i, "Got", result, "Expected", testCase.out, "Case:", testCase)
+		}
+	}
+	for pId, _ := range players {
+		for zId, _ := range zones {
+			times := earliestArrivals[pId][zId]
+			for k := 1; k < len(times); k += 1 {
+				if times[k] < times[k-1] {
+					t.Error("Arrivals should be sorted", pId, zId, times)
+				}
+			}
+		}
+	}
+}
+
+//Tests that the race tables answer the same as the arrival counts (see maxEnemiesNearZone)
+func TestEnemyEarliestArrival(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"frontier\\input.txt", t)
+	for zId, _ := range zones {
+		for numDrones := 1; numDrones <= numDronesPerplayer; numDrones += 1 {
+			for dist := 0; dist <= rules.maxDistance; dist += 1 {
+				if (enemyEarliestArrival(zId, numDrones) <= dist) != (maxEnemiesNearZone(zId, dist) >= numDrones) {
+					t.Error("Race and arrivals disagree in zone", zId, "for", numDrones, "drones in", dist, "turns")
+				}
+			}
+		}
+	}
+	if enemyEarliestArrival(0, 0) != 0 || enemyEarliestArrival(0, numDronesPerplayer+1) != rules.maxDistance+1 {
+		t.Error("Wrong limits of the race")
+	}
+}
+
+//Tests method calculateFrontier
+func TestFrontier(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"frontier\\input.txt", t)
+	expected := []int{SAFELY_MINE, SAFELY_THEIRS, CONTESTED}
+	for zId, status := range expected {
+		if frontier[zId] != status {
+			t.Error("Wrong status of zone", zId, "Got", frontierName(frontier[zId]), "Expected", frontierName(status))
+		}
+	}
+	if spareDrones[0] != 2 || spareDrones[1] != 3 {
+		t.Error("Wrong spare drones. Got", spareDrones, "Expected [2 3]")
+	}
+	if isContested(0) || isContested(1) || !isContested(2) {
+		t.Error("Only zone 2 should be contested")
+	}
+}
+
+//Tests that the frontier is lost when an enemy can outnumber the owner in time
+func TestFrontierOutnumbered(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"frontier\\input.txt", t)
+	players[1].drones[1] = point{800, 500}
+	players[1].drones[2] = point{800, 600}
+	players[1].drones[0] = point{700, 500}
+	players[0].drones[2] = point{3000, 200}
+	calculateDistances()
+	calculateArrivals()
+	calculateFrontier()
+	if frontier[0] != CONTESTED {
+		t.Error("Zone 0 should be contested. Got", frontierName(frontier[0]))
+	}
+	if spareDrones[0] != 3 {
+		t.Error("My drones should all be spare when no zone is safe. Got", spareDrones[0])
+	}
+}
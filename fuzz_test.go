@@ -0,0 +1,114 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const FUZZ_CRASHERS_DIR = "fuzz" //Folder of testInputs where the inputs that crash the fuzz targets are saved
+
+//Fuzzes the parser with arbitrary boards and turns. The accepted ones are played too
+func FuzzReadBoardAndTurn(f *testing.F) {
+	for _, path := range gameInputs(f) {
+		if data, err := os.ReadFile(path); err == nil {
+			f.Add(string(data))
+		}
+	}
+	f.Add("")
+	f.Add("2 0 2 1\n500 500\n7\n1 1\n2 2\n3 3\n4 4\n")
+	f.Add("2 0 2 -1\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		defer saveCrasher(t, func() string { return input })
+		inputReader = strings.NewReader(input)
+		if readBoard() && parseTurn() {
+			checkPlay(t)
+		}
+	})
+}
+
+//Fuzzes the decisions with random but valid games of a few turns
+func FuzzPlay(f *testing.F) {
+	f.Add(int64(1), uint8(2), uint8(3), uint8(4), uint8(3))
+	f.Add(int64(2), uint8(4), uint8(11), uint8(8), uint8(5))
+	f.Add(int64(3), uint8(3), uint8(1), uint8(1), uint8(1))
+	f.Fuzz(func(t *testing.T, seed int64, nPlayers, nDrones, nZones, nTurns uint8) {
+		defer saveCrasher(t, importableStatus)
+		rng := rand.New(rand.NewSource(seed))
+		board := botBoard{2 + int(nPlayers)%3, 0, 1 + int(nDrones)%11, make([][2]int, 1+int(nZones)%8), gameRules{}}
+		board.Whoami = rng.Intn(board.NumPlayers)
+		for zId, _ := range board.Zones {
+			board.Zones[zId] = [2]int{rng.Intn(rules.Width), rng.Intn(rules.Height)}
+		}
+		if err := setBoard(board); err != nil {
+			t.Fatal("Random boards should be valid:", err)
+		}
+		for step := 0; step < 1+int(nTurns)%5; step += 1 {
+			if err := setTurn(randomTurn(rng)); err != nil {
+				t.Fatal("Random turns should be valid:", err)
+			}
+			checkPlay(t)
+		}
+	})
+}
+
+//Returns a turn of the current board with random owners and drones
+func randomTurn(rng *rand.Rand) (result botTurn) {
+	result = botTurn{make([]int, numZones), make([][][2]int, numPlayers)}
+	for zId, _ := range result.Owners {
+		result.Owners[zId] = rng.Intn(numPlayers+1) - 1
+	}
+	for pId, _ := range result.Drones {
+		result.Drones[pId] = make([][2]int, numDronesPerplayer)
+		for dId, _ := range result.Drones[pId] {
+			if rng.Intn(2) == 0 { //Half of the drones in a zone, the rest anywhere
+				z := zones[rng.Intn(numZones)].pos
+				result.Drones[pId][dId] = [2]int{z.x, z.y}
+			} else {
+				result.Drones[pId][dId] = [2]int{rng.Intn(rules.Width), rng.Intn(rules.Height)}
+			}
+		}
+	}
+	return result
+}
+
+//Plays the current turn and checks that a move inside the board is printed for each drone and that nothing panicked.
+//Panics are recovered while playing (see safeRun), so they are raised again to be saved (see saveCrasher)
+func checkPlay(t *testing.T) {
+	panicsBefore := panicCount
+	lines := strings.Split(strings.TrimSpace(captureOutput(play, t)), "\n")
+	if panicCount > panicsBefore {
+		panic(fmt.Sprint(panicCount-panicsBefore, " panics recovered while playing turn ", turn, ": ", turnNotes))
+	}
+	if len(lines) != numDronesPerplayer {
+		t.Fatal("Wrong number of moves:", lines)
+	}
+	for dId, line := range lines {
+		var x, y int
+		if n, err := fmt.Sscanf(line, "%d %d", &x, &y); n != 2 || err != nil || x < 0 || x >= rules.Width || y < 0 || y >= rules.Height {
+			t.Error("Wrong move of drone", dId, line)
+		}
+	}
+}
+
+//Saves the input that made the fuzz target panic into testInputs (so it is also played by the tests of every input)
+//and panics again
+func saveCrasher(t *testing.T, input func() string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	data := strings.TrimLeft(input(), "\n")
+	dir := filepath.Join(filepath.FromSlash(strings.Replace(FILE_TESTS_BASE, "\\", "/", -1)), FUZZ_CRASHERS_DIR)
+	path := filepath.Join(dir, strings.Replace(t.Name(), "/", "_", -1)+".txt") //Smaller inputs found while minimizing replace it
+	if err := os.MkdirAll(dir, 0755); err != nil || os.WriteFile(path, []byte(data), 0644) != nil {
+		t.Log("Error saving the crashing input", path)
+	} else {
+		t.Log("Crashing input saved in", path)
+	}
+	panic(r)
+}
@@ -10,21 +10,22 @@ import (
 	"runtime/debug"
 	"runtime/pprof"
 	"sort"
+	"strings"
 	"time"
 )
 
 /************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
 const (
-	DEBUG              = false  //True iff traces are activated
-	PROFILING          = false  //True iff profiling must be activated
-	ZONE_RADIUS        = 100.0  //Radius of the zones
-	DRONE_MOVEMENT     = 100.0  //Maximum movement of a drone in a turn
-	MAX_DISTANCE       = 44     //Number of turns to cross the board
-	BOARD_DIAGONAL     = 4387.0 //Length of the diagonal of the board (maximum distance between two points)
-	UNRECLAIMED        = -1     //Owner of unreclaimed zones
-	NUM_TURNS_TO_CHECK = 5      //Number of turns to try to look into the future
+	DEBUG              = false //True iff traces are activated
+	PROFILING          = false //True iff profiling must be activated
+	UNRECLAIMED        = -1    //Owner of unreclaimed zones
+	MAX_ZONES          = 16    //Highest number of zones a board can have (the game uses up to 8)
+	MAX_PLAYERS        = 8     //Highest number of players a game can have (the game uses up to 4)
+	NUM_TURNS_TO_CHECK = 5     //Number of turns to try to look into the future
 )
 
+var NO_MOVE = point{-1, -1} //Move of the drones that have no destination yet (it is outside the board)
+
 const PROFILE_PATH = "C:\\Users\\borja\\programacion\\codinggame\\GameOfDronesSolution\\profile.pprof" //Path to the file that stores the profiling information
 
 var ( //board-related variables
@@ -36,17 +37,24 @@ var ( //board-related variables
 	whoami             int       //index of my player in the array of players
 	players            []player  //all the player of drones. Array index = player's ID
 	zones              []zone    //all game zones
-	centroid           point     //Centroid of the zones
+	turn               int       //Number of the current turn (the first one is 1)
+	previousDrones     [][]point //Position of each drone of each player in the previous turn. nil in the first turn
 )
 
+var attackScoreWeights = attackWeights{points: 1.0, drones: 2.0, arrival: 1.0, risk: 10.0, value: 5.0} //How attacks are scored
+
 var ( //turn-related variables
 	distances    [][][]int //Distances for each of the players, for each of the drones to each of the zones
+	arrivals     [][][]int //For each player, for each zone, number of drones that can reach it in each number of turns (or less)
+	maxEnemies   [][]int   //For each zone, highest number of drones of a single enemy that can reach it in each number of turns (or less)
 	nextMove     []point   //destination for each of my drones
 	availability struct {
 		numAvailables int   //number of drones with some degree of availability
 		drones        []int //Number of turns the drone can be traveling
 	}
 	//assignedDrones map[int]bool //Drones that have a destination asigned in this turn
+	attackPlans         []attackPlan //Attacks launched in this turn
+	previousAttackPlans []attackPlan //Attacks launched in the previous turn
 )
 
 /* CONSTANTS AND VARIABLES END ********************************************************************* DATA TYPES BEGIN */
@@ -71,39 +79,62 @@ func newZone() zone {
 }
 
 type attack struct {
-	target   int          //ID of the zone to attack
-	distance int          //Number of turns the farthest drone must travel
-	force    map[int]bool //Set of drones that will make the attack
+	target   int      //ID of the zone to attack
+	distance int      //Number of turns the farthest drone must travel
+	force    droneSet //Set of drones that will make the attack
+	score    float64  //How good the attack is. The higher, the better
 }
 
 //updates the length of the attack
 func (a *attack) calculateLength() {
 	a.distance = 0
-	for dId, _ := range a.force {
+	for dId := a.force.next(-1); dId >= 0; dId = a.force.next(dId) {
 		if calculatedDistance := turnBasedDistance(players[whoami].drones[dId], zones[a.target].pos); calculatedDistance > a.distance {
 			a.distance = calculatedDistance
 		}
 	}
 }
 
-//Implements sort.Interface
-type attackSorter []attack
+//Attack launched in a turn
+type attackPlan struct {
+	target  int      //ID of the zone to attack
+	arrival int      //Turn in which all the drones must enter the zone
+	force   droneSet //Set of drones that make the attack
+}
 
-//Necessary to implement sort.Interface
-func (as attackSorter) Less(i, j int) bool {
-	if len(as[i].force) < len(as[j].force) {
-		return true
+//Weights of each of the criteria used to score an attack
+type attackWeights struct {
+	points  float64 //Reward per point expected to be gained with the zone
+	drones  float64 //Penalty per drone committed to the attack
+	arrival float64 //Penalty per turn the farthest drone needs to arrive
+	risk    float64 //Penalty per owned zone that could be lost because its drones leave
+	value   float64 //Reward per unit of strategic value of the target zone
+}
+
+//Implements sort.Interface. Best attacks go first
+type attacksByScore []attack
+
+//Necessary to implement sort.Interface. Ties are broken by fewer drones, then shorter attacks, then lower zone ID
+func (as attacksByScore) Less(i, j int) bool {
+	if as[i].score != as[j].score {
+		return as[i].score > as[j].score
 	}
-	return as[i].distance < as[j].distance
+	if as[i].force.count() != as[j].force.count() {
+		return as[i].force.count() < as[j].force.count()
+	}
+	if as[i].distance != as[j].distance {
+		return as[i].distance < as[j].distance
+	}
+	return as[i].target < as[j].target
 }
 
 //Necessary to implement sort.Interface
-func (as attackSorter) Swap(i, j int) {
+func (as attacksByScore) Swap(i, j int) {
 	as[i], as[j] = as[j], as[i]
 }
 
 //Necessary to implement sort.Interface
-func (as attackSorter) Len() int {
+func (as attacksByScore) Len() int {
 	return len(as)
 }
 
@@ -113,29 +144,37 @@ func (as attackSorter) Len() int {
 //- while there is an attackable zone
 //  + For all attackable zones
 //    * Define attack
-//  + Choose best attack
+//    * Score attack
+//  + Choose the attack with the best score and launch it so that all its drones arrive at the same time
 func strategyAttack() {
-	attackableZones := make(map[int]bool, numZones)
+	attackableZones := make([]bool, numZones) //Array index = zone's ID. Zones are always checked in order
+	numAttackableZones := numZones
 	for zId, _ := range zones {
 		attackableZones[zId] = true
 	}
-	for len(attackableZones) > 0 {
+	for numAttackableZones > 0 {
 		trace("availability", availability)
 		attacks := make([]attack, 0, numZones)
-		for zId, _ := range attackableZones {
+		for zId, attackable := range attackableZones {
+			if !attackable {
+				continue
+			}
 			if a, attackable := bestAttackToZone(zId); attackable {
 				trace("Adding attack", a)
 				attacks = append(attacks, a)
 			} else {
-				delete(attackableZones, zId)
+				attackableZones[zId] = false
+				numAttackableZones--
 			}
 		}
 		if len(attacks) > 0 {
-			sort.Sort(attackSorter(attacks))
-			for dId, _ := range attacks[0].force {
-				assignDestinationZone(dId, attacks[0].target, "Zone must be ours!!!")
+			for i, _ := range attacks {
+				attacks[i].evaluate()
 			}
-			delete(attackableZones, attacks[0].target)
+			sort.Sort(attacksByScore(attacks))
+			launchAttack(attacks[0])
+			attackableZones[attacks[0].target] = false
+			numAttackableZones--
 		}
 	}
 	/*
@@ -155,7 +194,7 @@ func strategyAttack() {
 				}
 			}
 			if len(attacks) > 0 {
-				sort.Sort(attackSorter(attacks))
+				sort.Sort(attacksByScore(attacks))
 				a := attacks[0]
 				for dId, _ := range a.force {
 					assignDestinationZone(dId, a.target, "Zone must be ours!!!")
@@ -166,6 +205,53 @@ func strategyAttack() {
 	*/
 }
 
+//Calculates the movements for unasigned drones based on the following strategy:
+//- For each attack launched in the previous turn whose zone I still do not own
+//  + If the enemies can bring to the zone, in the time the attack needs, as many drones as I can: cancel it
+//  + Drones of cancelled attacks regroup in the point that threatens more zones
+func strategyRegroupFromHopelessAttacks() {
+	for _, plan := range previousAttackPlans {
+		if zones[plan.target].owner == whoami || !isHopeless(plan) {
+			continue
+		}
+		turnInfo("Attack to zone", plan.target, "cancelled: enemies can bring", maxEnemiesNearZone(plan.target, attackTimeLeft(plan)),
+			"drones and I can only bring", dronesAbleToReachZone(plan.target, attackTimeLeft(plan)))
+		for dId := plan.force.next(-1); dId >= 0; dId = plan.force.next(dId) {
+			if isAssigned(dId) {
+				continue
+			}
+			assignDestinationPointNoisy(dId, regroupRallyPoint(dId), fmt.Sprint("Regrouping after cancelling the attack to zone ", plan.target))
+		}
+	}
+}
+
+//Calculates the movements for unasigned drones based on the following strategy:
+//- For each zone owned by an enemy whose drones are leaving it (and no other enemy is near)
+//  + The nearest free drone goes there to arrive when the last enemy drone leaves (waits if it is too near)
+func strategyCaptureVacatedZones() {
+	if previousDrones == nil {
+		return
+	}
+	for zId, z := range zones {
+		if z.owner == whoami || z.owner == UNRECLAIMED {
+			continue
+		}
+		emptyIn := turnsToBeVacated(zId)
+		if emptyIn < 0 || thirdPartiesNearZone(zId, emptyIn) {
+			continue
+		}
+		dId := nearestFreeOwnDroneToZone(zId)
+		if dId < 0 {
+			continue
+		}
+		if dist := distances[whoami][dId][zId]; dist < emptyIn {
+			assignDestinationPointNoisy(dId, players[whoami].drones[dId], fmt.Sprint("Waiting for zone ", zId, " to be empty in ", emptyIn, " turns"))
+		} else {
+			assignDestinationZone(dId, zId, fmt.Sprint("Its owner is leaving it. It will be empty in ", emptyIn, " turns"))
+		}
+	}
+}
+
 //Calculates the movements for unasigned drones based on the following strategy:
 //- If (1+ drone is inside an owned zone AND there are enemies in the same zone)
 //    air superiority cannot be lost (cannot abandon zone and leave air superiority to the oponent)
@@ -175,7 +261,7 @@ func strategyMaintainAirSuperiority() {
 			myDrones := playerDronesNearZone(whoami, zId, 0)
 			numHostiles := mostDronesBySingleOponentInZone(zId)
 			i := 0
-			for dId, _ := range myDrones {
+			for dId := myDrones.next(-1); dId >= 0; dId = myDrones.next(dId) {
 				if !isAssigned(dId) {
 					if i >= numHostiles {
 						break
@@ -188,17 +274,39 @@ func strategyMaintainAirSuperiority() {
 	}
 }
 
+//Calculates the movements of the first turn based on the following strategy:
+//- Each zone I can reach before any oponent (see startAdvantage) gets the nearest free drone that can reach it
+//- Zones with a bigger advantage go first, then the isolated ones (see nearestZoneDistance): they are the hardest to
+//  contest for the oponents
+func strategyOpening() {
+	if turn != 1 {
+		return
+	}
+	zIds := make([]int, 0, numZones)
+	for zId, z := range zones {
+		if z.owner != whoami && startAdvantage(zId) > 0 {
+			zIds = append(zIds, zId)
+		}
+	}
+	sort.Sort(openingSorter(zIds))
+	for _, zId := range zIds {
+		if dId := nearestFreeOwnDroneToZone(zId); dId >= 0 {
+			assignDestinationZone(dId, zId, fmt.Sprint("I get there ", startAdvantage(zId), " turns before anyone else"))
+		}
+	}
+}
+
 //Calculates the movements for the remaining drones based on the following strategy:
-//- Each remaining drone moves to the centre of its nearest zone
+//- Each remaining drone moves to the centre of its nearest zone (the one with the lowest ID in case of tie)
 func strategyDefaultToNearestZone() {
 	for dId := 0; dId < numDronesPerplayer; dId += 1 {
 		if isAssigned(dId) {
 			continue
 		}
-		minDist := MAX_DISTANCE
+		minDist := rules.maxDistance
 		bestZone := -1
 		for zId := 0; zId < numZones; zId += 1 {
-			if distances[whoami][dId][zId] <= minDist {
+			if distances[whoami][dId][zId] < minDist || bestZone == -1 {
 				minDist = distances[whoami][dId][zId]
 				bestZone = zId
 			}
@@ -208,13 +316,29 @@ func strategyDefaultToNearestZone() {
 }
 
 //Calculates the movements for the remaining drones based on the following strategy:
-//- Each remaining drone moves to the centroid of the board
-func strategyDefaultToCentroid() {
+//- Each remaining drone moves to its nearest rally point (see rallyPoints) if it can safely fly there
+func strategyDefaultToRallyPoints() {
+	for dId := 0; dId < numDronesPerplayer; dId += 1 {
+		if isAssigned(dId) {
+			continue
+		}
+		if rp := nearestRallyPoint(dId); turnBasedDistance(players[whoami].drones[dId], rp) <= availableDistance(dId) {
+			assignDestinationPointNoisy(dId, rp, "Going to the rally point to support my comrades")
+		}
+	}
+}
+
+//Calculates the movements for the remaining drones based on the following strategy:
+//- Each remaining drone is stationed in the cell of the influence map that best reaches the contested zones
+//  without being in the reach of the enemies
+func strategyStationFreeDrones() {
 	for dId := 0; dId < numDronesPerplayer; dId += 1 {
 		if isAssigned(dId) {
 			continue
 		}
-		assignDestinationPointNoisy(dId, centroid, "Going to the centroid to support my comrades")
+		station := bestStationPoint(dId)
+		addStationedDrone(station)
+		assignDestinationPointNoisy(dId, station, "Waiting near the contested zones")
 	}
 }
 
@@ -227,32 +351,200 @@ func bestAttackToZone(zId int) (result attack, isAttackable bool) {
 		result.target = zId
 		var ordersMustBeGiven bool
 		trace("Zone", zId, "is not mine. Let's try to get it")
-		result.force = make(map[int]bool, numDronesPerplayer)
+		result.force = 0
 		dist := 0
 		enemies := maxEnemiesNearZone(zId, dist)
-		for len(result.force) <= enemies && dist <= MAX_DISTANCE {
-			trace("Iterating because we still do not have enoug forces at distance", dist, ":", enemies, "Vs", len(result.force))
-			ownPossibilities := playerDronesNearZone(whoami, zId, dist)
+		for result.force.count() <= enemies && dist <= rules.maxDistance {
+			trace("Iterating because we still do not have enoug forces at distance", dist, ":", enemies, "Vs", result.force.count())
+			ownPossibilities := playerDronesNearZone(whoami, zId, dist).difference(result.force)
 			droneForTheAttack, mustMove := nearestOwnDroneToGoFromSet(zones[zId].pos, ownPossibilities)
 			ordersMustBeGiven = ordersMustBeGiven || mustMove
-			for droneForTheAttack != -1 && len(result.force) <= enemies {
-				result.force[droneForTheAttack] = true
-				delete(ownPossibilities, droneForTheAttack)
+			for droneForTheAttack != -1 && result.force.count() <= enemies {
+				result.force.add(droneForTheAttack)
+				ownPossibilities.remove(droneForTheAttack)
 				droneForTheAttack, mustMove = nearestOwnDroneToGoFromSet(zones[zId].pos, ownPossibilities)
 				ordersMustBeGiven = ordersMustBeGiven || mustMove
 			}
-			if enemies < len(result.force) {
+			if enemies < result.force.count() {
 				break
 			}
 			dist++
 			enemies = maxEnemiesNearZone(zId, dist)
 		}
-		if dist > MAX_DISTANCE || !ordersMustBeGiven {
-			result.force = make(map[int]bool)
+		if dist > rules.maxDistance || !ordersMustBeGiven {
+			result.force = 0
 		}
 	}
 	result.calculateLength()
-	return result, len(result.force) > 0
+	return result, result.force.count() > 0
+}
+
+//Sends the drones of the attack so that all of them enter the zone in the same turn: the farthest ones go to the zone
+//and the rest wait at a staging point of their path (see stagingPoint). If the attack was launched in the previous turn
+//and a drone has been delayed, the arrival is re-timed
+func launchAttack(a attack) {
+	plan := attackPlan{a.target, turn + a.distance, a.force}
+	for _, previous := range previousAttackPlans {
+		if previous.target == plan.target && previous.force&plan.force != 0 && previous.arrival < plan.arrival {
+			turnInfo("Attack to zone", plan.target, "re-timed from turn", previous.arrival, "to turn", plan.arrival, "because some drone is delayed")
+		}
+	}
+	for dId := a.force.next(-1); dId >= 0; dId = a.force.next(dId) {
+		if dist := distances[whoami][dId][a.target]; dist > 0 && dist < a.distance {
+			assignDestinationPointNoisy(dId, stagingPoint(dId, a.target), fmt.Sprint("Waiting to enter zone ", a.target, " with the rest of the attack in turn ", plan.arrival))
+		} else {
+			assignDestinationZone(dId, a.target, "Zone must be ours!!!")
+		}
+	}
+	attackPlans = append(attackPlans, plan)
+}
+
+//Returns where a drone of an attack that would arrive too early waits: the point of its straight path to the zone (one
+//per turn of flight, before entering it) that is farthest from the reach of the enemies (up to SAFE_TURNS), the nearest
+//to the zone in case of tie. Points inside other zones I do not own are skipped. The drone stays if none is left
+func stagingPoint(dId, zId int) point {
+	best, bestReach := players[whoami].drones[dId], -1
+	for p, k := best, 0; k < distances[whoami][dId][zId]; p, k = moveTowards(p, zones[zId].pos), k+1 {
+		if insideZoneNotMine(p, zId) {
+			continue
+		}
+		if reach := minInt(influence.enemyReach[cellOf(p)], SAFE_TURNS); reach >= bestReach {
+			best, bestReach = p, reach
+		}
+	}
+	return best
+}
+
+//Returns true iff the point is inside a zone I do not own other than the given one
+func insideZoneNotMine(p point, except int) bool {
+	for zId, z := range zones {
+		if zId != except && z.owner != whoami && turnBasedDistance(p, z.pos) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+//Returns the number of turns the attack still needs: the planned ones or more if some drone is delayed
+func attackTimeLeft(plan attackPlan) int {
+	result := plan.arrival - turn
+	for dId := plan.force.next(-1); dId >= 0; dId = plan.force.next(dId) {
+		result = maxInt(result, distances[whoami][dId][plan.target])
+	}
+	return result
+}
+
+//Returns true iff, in the time the attack still needs, a single enemy can bring to the zone at least as many drones as I
+//can (see enemyEarliestArrival)
+func isHopeless(plan attackPlan) bool {
+	timeLeft := attackTimeLeft(plan)
+	return enemyEarliestArrival(plan.target, dronesAbleToReachZone(plan.target, timeLeft)) <= timeLeft
+}
+
+//Returns the number of my drones that are in the zone or are free to reach it in the given number of turns. Drones
+//hidden from the running squad behaviour count too (see runBehaviour)
+func dronesAbleToReachZone(zId, dist int) (result int) {
+	for dId, _ := range players[whoami].drones {
+		if d := distances[whoami][dId][zId]; d == 0 || (d <= dist && unhiddenAvailableDistance(dId) >= d) {
+			result++
+		}
+	}
+	return result
+}
+
+//Returns the number of turns until the last drone of the owner of the zone leaves it, assuming every drone keeps its
+//speed and direction. Returns -1 if some of them is not leaving, another one is coming in before the zone is empty or
+//none of them was in the zone in the previous turn
+func turnsToBeVacated(zId int) (result int) {
+	owner := zones[zId].owner
+	leaving := false
+	var incoming []int
+	for dId, d := range players[owner].drones {
+		previous := previousDrones[owner][dId]
+		if distances[owner][dId][zId] > 0 {
+			leaving = leaving || turnBasedDistance(previous, zones[zId].pos) == 0
+			if euclideanDistance(d, zones[zId].pos) < euclideanDistance(previous, zones[zId].pos) {
+				incoming = append(incoming, dId)
+			}
+			continue
+		}
+		if euclideanDistance(d, zones[zId].pos) <= euclideanDistance(previous, zones[zId].pos) {
+			return -1 //Staying or coming in
+		}
+		leaving = true
+		speed := point{d.x - previous.x, d.y - previous.y}
+		exit := 1
+		for ; exit < rules.maxDistance && turnBasedDistance(point{d.x + exit*speed.x, d.y + exit*speed.y}, zones[zId].pos) == 0; exit += 1 {
+		}
+		result = maxInt(result, exit)
+	}
+	if !leaving {
+		return -1
+	}
+	for _, dId := range incoming {
+		if distances[owner][dId][zId] <= result {
+			return -1 //Reinforcements arrive before the zone is empty
+		}
+	}
+	return result
+}
+
+//Returns true iff drones of an enemy other than the owner of the zone can reach it in the given number of turns
+func thirdPartiesNearZone(zId, dist int) bool {
+	for pId, _ := range players {
+		if pId != whoami && pId != zones[zId].owner && playerDronesNearZoneCount(pId, zId, dist) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+//Returns the Id of the nearest free drone that can safely reach the zone, or -1 if there is none. Ties are broken by the lowest ID
+func nearestFreeOwnDroneToZone(zId int) int {
+	bestDrone := -1
+	for dId, _ := range players[whoami].drones {
+		dist := distances[whoami][dId][zId]
+		if isAssigned(dId) || availableDistance(dId) < dist {
+			continue
+		}
+		if bestDrone == -1 || dist < distances[whoami][bestDrone][zId] {
+			bestDrone = dId
+		}
+	}
+	return bestDrone
+}
+
+//Scores the attack based on the points expected to be gained in the remaining turns, the number of drones committed,
+//the time the farthest drone needs to arrive, the number of owned zones put at risk and the value of the target
+func (a *attack) evaluate() {
+	a.score = attackScoreWeights.points*float64(expectedPoints(a.distance)) +
+		attackScoreWeights.value*zoneValue(a.target) -
+		attackScoreWeights.drones*float64(a.force.count()) -
+		attackScoreWeights.arrival*float64(a.distance) -
+		attackScoreWeights.risk*float64(zonesAtRisk(a.force, a.distance))
+}
+
+//Returns the points a zone conquered in given number of turns would give until the end of the game
+func expectedPoints(arrival int) int {
+	if remaining := rules.MaxTurns - turn - arrival; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+//Returns the number of owned zones that could be lost if given drones leave them during given number of turns
+func zonesAtRisk(force droneSet, dist int) (result int) {
+	for zId, z := range zones {
+		if z.owner != whoami {
+			continue
+		}
+		inZone := playerDronesNearZone(whoami, zId, 0)
+		leaving, staying := inZone.count()-inZone.difference(force).count(), inZone.difference(force).count()
+		if leaving > 0 && enemyEarliestArrival(zId, staying+1) <= dist {
+			result++
+		}
+	}
+	return result
 }
 
 /*
@@ -310,12 +602,16 @@ func attackable(zId int) bool {
 /* ATTACK UTILITIES END ********************************************************************* GENERAL UTILITIES BEGIN */
 //Clears old turn's data and calculates this turn key information
 func initializeTurnComputation() {
+	previousAttackPlans, attackPlans = attackPlans, nil
 	calculateDistances()
-	nextMove = make([]point, numDronesPerplayer)
+	calculateArrivals()
+	calculateFrontier()
+	updateInfluenceMap()
+	nextMove = noMoves(numDronesPerplayer)
 	availability.numAvailables = numDronesPerplayer
 	availability.drones = make([]int, numDronesPerplayer)
 	for i, _ := range availability.drones {
-		availability.drones[i] = MAX_DISTANCE
+		availability.drones[i] = rules.maxDistance
 	}
 	trace("Availability XXX", availability)
 }
@@ -324,7 +620,7 @@ func initializeTurnComputation() {
 func calculateDonesAvailableDistances() {
 	for zId, _ := range zones {
 		if zones[zId].owner == whoami {
-			for i := 0; i < MAX_DISTANCE; i += 1 {
+			for i := 0; i < rules.maxDistance; i += 1 {
 				myDronesSet := playerDronesNearZone(whoami, zId, i)
 				/*
 					myDrones := make([]bool, numDronesPerplayer)
@@ -333,12 +629,14 @@ func calculateDonesAvailableDistances() {
 					}
 				*/
 				numEnemies := maxEnemiesNearZone(zId, i)
-				if numEnemies > len(myDronesSet) {
+				if numEnemies > myDronesSet.count() {
 					break //Nothing to do. Air superiority is lost at this distance.
 				}
 				numDronesLocked := 0
-				for dId, _ := range myDronesSet {
-					if i == 0 {
+				for _, dId := range nearestFirst(myDronesSet, zId) { //The ones in the zone are locked before the ones that could come back
+					if i == 0 && isAssigned(dId) { //It is in several zones (they overlap): staying keeps all of them
+						assignDestinationPointNoisy(dId, players[whoami].drones[dId], "Guarding several zones")
+					} else if i == 0 {
 						assignDestinationZone(dId, zId, "Too risky to move")
 					} else {
 						setAvailableDistance(dId, i-1)
@@ -355,55 +653,68 @@ func calculateDonesAvailableDistances() {
 }
 
 //Calculates the maximum number of foes from the same enemy at given distance of given zone
-func maxEnemiesNearZone(zId, dist int) (result int) {
-	for pId, _ := range players {
-		if pId == whoami {
-			continue
-		}
-		if num := len(playerDronesNearZone(pId, zId, dist)); num > result {
-			result = num
-		}
+func maxEnemiesNearZone(zId, dist int) int {
+	if dist < 0 {
+		return 0
 	}
-	return
+	return maxEnemies[zId][arrivalIndex(dist)]
 }
 
 //Returns the number of drones of the oponent who has most oponents in the given zone
 func mostDronesBySingleOponentInZone(zId int) int {
-	result := 0
-	for pId, _ := range players {
-		if pId == whoami {
-			continue
-		}
-		if currentPlayerDronesInZone := len(playerDronesNearZone(pId, zId, 0)); currentPlayerDronesInZone > result {
-			result = currentPlayerDronesInZone
+	return maxEnemiesNearZone(zId, 0)
+}
+
+//Returns the number of drones of given player that are inside given distance of given zone
+func playerDronesNearZoneCount(pId, zId, dist int) int {
+	if dist < 0 {
+		return 0
+	}
+	return arrivals[pId][zId][arrivalIndex(dist)]
+}
+
+//Returns the position of the given (non negative) distance in the arrival tables
+func arrivalIndex(dist int) int {
+	if dist > rules.maxDistance {
+		return rules.maxDistance
+	}
+	return dist
+}
+
+//Returns a set of ids of the drones of given player that are inside given distance of given zone
+func playerDronesNearZone(pId, zId, dist int) (result droneSet) {
+	for dId, _ := range players[pId].drones {
+		if distances[pId][dId][zId] <= dist {
+			result.add(dId)
 		}
 	}
 	return result
 }
 
-//Returns a set of ids of the drones of given player that are inside given distance of given zone
-func playerDronesNearZone(pId, zId, dist int) map[int]bool {
-	result := make(map[int]bool)
-	for dId, d := range players[pId].drones {
-		if turnBasedDistance(zones[zId].pos, d) <= dist {
-			result[dId] = true
+//Returns the given drones of mine sorted by their distance to the zone, the nearest first (lowest ID in case of tie)
+func nearestFirst(set droneSet, zId int) (result []int) {
+	for dist := 0; len(result) < set.count(); dist += 1 {
+		for dId := set.next(-1); dId >= 0; dId = set.next(dId) {
+			if distances[whoami][dId][zId] == dist {
+				result = append(result, dId)
+			}
 		}
 	}
 	return result
 }
 
-//Returns the Id of the nearest drone from the set of drones suplied.
+//Returns the Id of the nearest drone from the set of drones suplied. Ties are broken by the lowest ID.
 //- The drone is free to do the movement: returns the drone id and true
 //- The drone is inside the zone and assigned to remain still: returns the drone id and false
 //- There is no suitable drone: returns -1 and false
-func nearestOwnDroneToGoFromSet(p point, set map[int]bool) (int, bool) {
-	minDist := BOARD_DIAGONAL
+func nearestOwnDroneToGoFromSet(p point, set droneSet) (int, bool) {
+	minDist := rules.diagonal
 	bestDrone := -1
-	for dId, _ := range set {
+	for dId := set.next(-1); dId >= 0; dId = set.next(dId) {
 		if isAssigned(dId) && turnBasedDistance(p, players[whoami].drones[dId]) == 0 && turnBasedDistance(nextMove[dId], p) == 0 {
 			return dId, false
 		}
-		if currentDistance := euclideanDistance(players[whoami].drones[dId], p); currentDistance <= minDist && availableDistance(dId) >= turnBasedDistance(players[whoami].drones[dId], p) && !isAssigned(dId) {
+		if currentDistance := euclideanDistance(players[whoami].drones[dId], p); (currentDistance < minDist || bestDrone == -1) && availableDistance(dId) >= turnBasedDistance(players[whoami].drones[dId], p) && !isAssigned(dId) {
 			minDist = currentDistance
 			bestDrone = dId
 		}
@@ -411,13 +722,14 @@ func nearestOwnDroneToGoFromSet(p point, set map[int]bool) (int, bool) {
 	return bestDrone, bestDrone >= 0
 }
 
-//Returns the Id of the nearest drone I control (and has not been sent to other duties) to the given point
+//Returns the Id of the nearest drone I control (and has not been sent to other duties) to the given point.
+//Ties are broken by the lowest ID
 func nearestFreeOwnDrone(p point) int {
-	minDist := BOARD_DIAGONAL
+	minDist := rules.diagonal
 	bestDrone := -1
 	for dId, d := range players[whoami].drones {
 		if availableDistance(dId) >= turnBasedDistance(d, p) {
-			if currentDistance := euclideanDistance(d, p); currentDistance <= minDist {
+			if currentDistance := euclideanDistance(d, p); currentDistance < minDist || bestDrone == -1 {
 				minDist = currentDistance
 				bestDrone = dId
 			}
@@ -456,6 +768,14 @@ func assignDestinationPoint(dId int, p point) {
 	nextMove[dId] = p
 }
 
+//Stores the current position of every drone as the one of the previous turn
+func rememberPositions() {
+	previousDrones = make([][]point, numPlayers)
+	for pId, p := range players {
+		previousDrones[pId] = append([]point(nil), p.drones...)
+	}
+}
+
 //Calculates the distances from each of my drones to each of the zones' centres
 func calculateDistances() {
 	for pId, _ := range players {
@@ -467,13 +787,42 @@ func calculateDistances() {
 	}
 }
 
+//Calculates, for each player and zone, how many drones can reach the zone in each number of turns (or less), and
+//the highest of those numbers among my enemies
+func calculateArrivals() {
+	for pId, _ := range players {
+		for zId, _ := range zones {
+			counts := arrivals[pId][zId]
+			for dist, _ := range counts {
+				counts[dist] = 0
+			}
+			for dId, _ := range players[pId].drones {
+				counts[arrivalIndex(distances[pId][dId][zId])]++
+			}
+			for dist := 1; dist <= rules.maxDistance; dist += 1 {
+				counts[dist] += counts[dist-1]
+			}
+		}
+	}
+	for zId, _ := range zones {
+		for dist := 0; dist <= rules.maxDistance; dist += 1 {
+			maxEnemies[zId][dist] = 0
+			for pId, _ := range players {
+				if pId != whoami && arrivals[pId][zId][dist] > maxEnemies[zId][dist] {
+					maxEnemies[zId][dist] = arrivals[pId][zId][dist]
+				}
+			}
+		}
+	}
+}
+
 //Calculates the number of turns that it would take a drone to move from pointA to pointB
 func turnBasedDistance(pointA, pointB point) int {
 	euc := euclideanDistance(pointA, pointB)
-	if euc < ZONE_RADIUS {
+	if euc < rules.ZoneRadius {
 		return 0
 	}
-	return int(math.Ceil((euc - (ZONE_RADIUS)) / DRONE_MOVEMENT))
+	return int(math.Ceil((euc - (rules.ZoneRadius)) / rules.DroneSpeed))
 }
 
 //Returns the euclidean distance between two points
@@ -514,26 +863,79 @@ func availableDistance(dId int) int {
 	return availability.drones[dId]
 }
 
-//Sets the distance the drone can safely fly
+//Returns the given number of moves without destination
+func noMoves(n int) []point {
+	result := make([]point, n)
+	for i, _ := range result {
+		result[i] = NO_MOVE
+	}
+	return result
+}
+
+//Sets the distance the drone can safely fly. A drone that cannot fly at all stays where it is
 func setAvailableDistance(dId, dist int) {
 	if availability.drones[dId] > dist {
+		if dist == 0 {
+			assignDestinationPointNoisy(dId, players[whoami].drones[dId], "Too risky to move")
+			return
+		}
 		availability.drones[dId] = dist
 	}
 }
 
+//Returns the minimum of two integers
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+//Returns the maximum of two integers
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+//Returns the absolute value of an integer
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
 /* GENERAL UTILITIES END   *********************************************** INPUT PARSING - RELATED OPERATIONS BEGIN ***/
-//Reads the game initialization information
-func readBoard() {
-	fmt.Fscanf(inputReader, "%d %d %d %d\n", &numPlayers, &whoami, &numDronesPerplayer, &numZones)
-	players = make([]player, numPlayers)
-	for i, _ := range players {
-		players[i].drones = make([]point, numDronesPerplayer)
-	}
-	zones = make([]zone, numZones)
-	for i, _ := range zones {
-		zones[i] = newZone()
-		fmt.Fscanf(inputReader, "%d %d\n", &zones[i].pos.x, &zones[i].pos.y)
+//Reads the game initialization information. Returns false if it is wrong or incomplete
+func readBoard() bool {
+	b := botBoard{Rules: rules} //The rules of the game played (see commandPlay)
+	var nZones int
+	if _, err := fmt.Fscanf(inputReader, "%d %d %d %d\n", &b.NumPlayers, &b.Whoami, &b.NumDrones, &nZones); err != nil {
+		fmt.Fprintln(os.Stderr, "Error reading board:", err)
+		return false
+	}
+	if nZones < 0 || nZones > MAX_ZONES {
+		fmt.Fprintln(os.Stderr, "Error reading board: wrong number of zones", nZones)
+		return false
+	}
+	b.Zones = make([][2]int, nZones)
+	for i, _ := range b.Zones {
+		if _, err := fmt.Fscanf(inputReader, "%d %d\n", &b.Zones[i][0], &b.Zones[i][1]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading zone", i, "of the board:", err)
+			return false
+		}
+	}
+	if err := setBoard(b); err != nil {
+		fmt.Fprintln(os.Stderr, "Error reading board:", err)
+		return false
 	}
+	return true
+}
+
+//Allocates the tables of the turn computations and analyzes the board. Called once the zones are known
+func initializeBoard() {
 	distances = make([][][]int, numPlayers)
 	for pId := 0; pId < numPlayers; pId += 1 {
 		distances[pId] = make([][]int, numDronesPerplayer)
@@ -541,31 +943,56 @@ func readBoard() {
 			distances[pId][dId] = make([]int, numZones)
 		}
 	}
-	centroid = getCentroid(zones)
+	arrivals = make([][][]int, numPlayers)
+	for pId := 0; pId < numPlayers; pId += 1 {
+		arrivals[pId] = make([][]int, numZones)
+		for zId := 0; zId < numZones; zId += 1 {
+			arrivals[pId][zId] = make([]int, rules.maxDistance+1)
+		}
+	}
+	maxEnemies = make([][]int, numZones)
+	for zId := 0; zId < numZones; zId += 1 {
+		maxEnemies[zId] = make([]int, rules.maxDistance+1)
+	}
+	earliestArrivals = make([][][]int, numPlayers)
+	for pId := 0; pId < numPlayers; pId += 1 {
+		earliestArrivals[pId] = make([][]int, numZones)
+		for zId := 0; zId < numZones; zId += 1 {
+			earliestArrivals[pId][zId] = make([]int, numDronesPerplayer)
+		}
+	}
+	rallyCells = nil
+	resetSquads()
+	lastBestGenome = nil
+	analyzeZones()
+	initializeInfluenceMap()
 }
 
 //Reads the information of a turn
 func parseTurn() bool {
-	for i, _ := range zones {
-		_, err := fmt.Fscanf(inputReader, "%d\n", &zones[i].owner)
+	t := botTurn{make([]int, numZones), make([][][2]int, numPlayers)}
+	for i, _ := range t.Owners {
+		_, err := fmt.Fscanf(inputReader, "%d\n", &t.Owners[i])
 		if err != nil {
-			fmt.Println("Error reading turn zones owners:", err)
+			fmt.Fprintln(os.Stderr, "Error reading turn zones owners:", err)
 			return false
 		}
-		if zones[i].owner >= 0 {
-			players[zones[i].owner].score += 1
-		}
 	}
 
-	for i, _ := range players {
-		for j, _ := range players[i].drones {
-			_, err := fmt.Fscanf(inputReader, "%d %d\n", &players[i].drones[j].x, &players[i].drones[j].y)
+	for i, _ := range t.Drones {
+		t.Drones[i] = make([][2]int, numDronesPerplayer)
+		for j, _ := range t.Drones[i] {
+			_, err := fmt.Fscanf(inputReader, "%d %d\n", &t.Drones[i][j][0], &t.Drones[i][j][1])
 			if err != nil {
-				fmt.Println("Error reading turn drones:", err)
+				fmt.Fprintln(os.Stderr, "Error reading turn drones:", err)
 				return false
 			}
 		}
 	}
+	if err := setTurn(t); err != nil {
+		fmt.Fprintln(os.Stderr, "Error reading turn:", err)
+		return false
+	}
 	return true
 }
 
@@ -573,6 +1000,14 @@ func parseTurn() bool {
 
 //Unleashes the beast
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1:]))
+	}
+	playStandardInput()
+}
+
+//Plays the game reading the standard input
+func playStandardInput() {
 	realExecution = true
 	if PROFILING {
 		f, err := os.Create(PROFILE_PATH)
@@ -585,11 +1020,17 @@ func main() {
 	}
 	defer func() {
 		if r := recover(); r != nil {
-			fmt.Fprintf(os.Stderr, "\n\n\nRecovered while panicking. Status:\n", importableStatus(), "\n\n\n")
+			fmt.Fprintf(os.Stderr, "\n\n\nRecovered while panicking. Status:\n%s\n\n\n", importableStatus())
 			fmt.Fprint(os.Stderr, string(debug.Stack()))
 		}
 	}()
 
+	if RECORDING {
+		startRecording()
+	}
+	if err := loadEvalWeightsFile(EVAL_WEIGHTS_PATH); err != nil && !os.IsNotExist(err) {
+		fmt.Fprintln(os.Stderr, "Error loading evaluation weights:", err)
+	}
 	inputReader = os.Stdin
 	letTheGameBegin() //..hear the starting gun
 }
@@ -598,34 +1039,91 @@ func main() {
 func letTheGameBegin() {
 
 	tFrom := time.Now()
-	readBoard()
+	if !readBoard() {
+		return //The board is wrong or incomplete: there is no game to play
+	}
+	if replayWriter != nil {
+		recordBoard(replayWriter)
+	}
 	turnInfo("Initial status:", status())
 	turnInfo(fmt.Sprintf("Initialization computation time: %v microseconds", time.Now().Sub(tFrom).Nanoseconds()/1000))
-	for tFrom = time.Now(); parseTurn(); tFrom = time.Now() {
-		turnInfo("XXX")
-		turnInfo(importableStatus())
-		turnInfo("XXX")
-		turnInfo("Current status:", status())
+	for tFrom = time.Now(); nextTurn(); tFrom = time.Now() {
+		safeRun("status", func() {
+			turnInfo("XXX")
+			turnInfo(importableStatus())
+			turnInfo("XXX")
+			turnInfo("Current status:", status())
+		})
 		play()
+		if RENDER_TURNS {
+			safeRun("rendering", func() {
+				width, height := terminalSize()
+				turnInfo("Board:\n" + renderBoard(width, height, false))
+			})
+		}
 		turnInfo(fmt.Sprintf("Turn computation time: %v microseconds", time.Now().Sub(tFrom).Nanoseconds()/1000))
+		if replayWriter != nil {
+			safeRun("recording", func() { recordTurn(replayWriter) })
+		}
 	}
 	turnInfo("End status:", status())
 }
 
-//Prints the movements of own drones
+//Reads the next turn. If reading it panics, the turn is played anyway with what could be read (see safeRun)
+func nextTurn() (more bool) {
+	if !safeRun("turn input", func() { more = parseTurn() }) {
+		return true
+	}
+	return more
+}
+
+//Prints the movements of own drones. If a step panics, the turn goes on without it: every drone without a valid move
+//stays where it is
 func play() {
-	initializeTurnComputation()
+	decideMoves()
+	if !safeRun("move invariants", enforceMoveInvariants) {
+		completeMoves()
+	}
+	var output string
+	if !safeRun("printing", func() { output = movesOutput() }) {
+		nextMove = nil
+		completeMoves()
+		output = movesOutput()
+	}
+	fmt.Print(output)
+}
+
+//Returns the movements of own drones as they are printed: one line "x y" per drone
+func movesOutput() string {
+	var result bytes.Buffer
+	for _, m := range nextMove {
+		result.WriteString(fmt.Sprintln(m.x, m.y))
+	}
+	return result.String()
+}
+
+//Calculates the destination of each of my drones
+func decideMoves() {
+	panicsBefore := panicCount
+	if !safeRun("turn initialization", initializeTurnComputation) {
+		completeMoves() //Nothing else can be trusted: every drone stays
+		return
+	}
 
-	calculateDonesAvailableDistances()
+	safeRun("available distances", calculateDonesAvailableDistances)
 
-	strategyMaintainAirSuperiority()
+	if evolutionPlanner {
+		safeRun("air superiority", strategyMaintainAirSuperiority)
+		safeRun("evolution", strategyEvolution)
+	} else {
+		runSquads() //See squadBehaviours
+	}
 	//strategyColonizeTheUnexplored()
 	//strategyGoForUnguardedZones()
-	strategyAttack()
-	strategyDefaultToCentroid()
+	safeRun("station", strategyStationFreeDrones) //Drones no squad has moved, if any
 	//strategyDefaultToNearestZone()
-	for _, m := range nextMove {
-		fmt.Println(m.x, m.y)
+	if panicCount > panicsBefore {
+		completeMoves() //Strategies that panicked may have left drones without destination
 	}
 }
 
@@ -684,14 +1182,15 @@ func status() string {
 //Traces information to Standard Error output
 func trace(x ...interface{}) {
 	if DEBUG {
-		fmt.Fprintln(os.Stderr, x)
+		fmt.Fprintln(os.Stderr, x...)
 	}
 }
 
 //Writes the main information regarding the actions taken in the turn
 func turnInfo(x ...interface{}) {
+	turnNotes = append(turnNotes, strings.TrimSuffix(fmt.Sprintln(x...), "\n"))
 	if realExecution {
-		fmt.Fprintln(os.Stderr, x)
+		fmt.Fprintln(os.Stderr, x...)
 	}
 }
 
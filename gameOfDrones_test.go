@@ -2,11 +2,15 @@
 package main
 
 import (
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 )
 
-const FILE_TESTS_BASE = `C:\Users\borja\programacion\codinggame\GameOfDronesSolution\testInputs\`
+const FILE_TESTS_BASE = `testInputs\`
 
 //Tests method turnBasedDistance
 func TestTurnBasedDistance(t *testing.T) {
@@ -154,8 +158,7 @@ func TestMaintainAirSuperiority2Vs2(t *testing.T) {
 	}
 	destinations := make(map[point]bool, 2)
 	for d, _ := range getAssignedDrones() {
-		if (nextMove[d].x == 300 && nextMove[d].y == 300) ||
-			(nextMove[d].x == 300 && nextMove[d].y == 300) {
+		if nextMove[d].x == 300 && nextMove[d].y == 300 {
 			destinations[nextMove[d]] = true
 		}
 	}
@@ -188,8 +191,8 @@ func TestPlayerDronesNearZoneZero(t *testing.T) {
 	setUpTestFromFile(FILE_TESTS_BASE+"playerDronesInZone\\input0.txt", t)
 	drones := playerDronesNearZone(0, 2, 0)
 
-	if len(drones) != 0 {
-		t.Error("Wrong number of drones in zone:", len(drones))
+	if drones.count() != 0 {
+		t.Error("Wrong number of drones in zone:", drones.count())
 	}
 }
 
@@ -199,10 +202,10 @@ func TestPlayerDronesNearZoneOne(t *testing.T) {
 
 	drones := playerDronesNearZone(0, 2, 0)
 
-	if len(drones) != 1 {
-		t.Error("Wrong number of drones in zone:", len(drones))
+	if drones.count() != 1 {
+		t.Error("Wrong number of drones in zone:", drones.count())
 	}
-	if _, isThere := drones[0]; !isThere {
+	if !drones.contains(0) {
 		t.Error("Wrong drone in the zone", drones)
 	}
 }
@@ -212,13 +215,13 @@ func TestPlayerDronesNearZoneTwo(t *testing.T) {
 	setUpTestFromFile(FILE_TESTS_BASE+"playerDronesInZone\\input2.txt", t)
 	drones := playerDronesNearZone(0, 2, 0)
 
-	if len(drones) != 2 {
-		t.Error("Wrong number of drones in zone:", len(drones))
+	if drones.count() != 2 {
+		t.Error("Wrong number of drones in zone:", drones.count())
 	}
-	if _, isThere := drones[0]; !isThere {
+	if !drones.contains(0) {
 		t.Error("Wrong drone in the zone", drones)
 	}
-	if _, isThere := drones[1]; !isThere {
+	if !drones.contains(1) {
 		t.Error("Wrong drone in the zone", drones)
 	}
 }
@@ -227,42 +230,42 @@ func TestPlayerDronesNearZoneTwo(t *testing.T) {
 func TestPlayerDronesNearZoneIncrementalDistance(t *testing.T) {
 	setUpTestFromFile(FILE_TESTS_BASE+"playerDronesInZone\\input3.txt", t)
 	drones := playerDronesNearZone(0, 0, 0)
-	if len(drones) != 1 {
-		t.Error("Wrong number of drones in zone:", len(drones))
+	if drones.count() != 1 {
+		t.Error("Wrong number of drones in zone:", drones.count())
 	}
-	if _, isThere := drones[0]; !isThere {
+	if !drones.contains(0) {
 		t.Error("Wrong drone in the zone", drones)
 	}
 
 	drones = playerDronesNearZone(0, 0, 1)
-	if len(drones) != 2 {
-		t.Error("Wrong number of drones in zone:", len(drones))
+	if drones.count() != 2 {
+		t.Error("Wrong number of drones in zone:", drones.count())
 	}
-	if _, isThere := drones[0]; !isThere {
+	if !drones.contains(0) {
 		t.Error("Wrong drone in the zone", drones)
 	}
-	if _, isThere := drones[1]; !isThere {
+	if !drones.contains(1) {
 		t.Error("Wrong drone in the zone", drones)
 	}
 
 	drones = playerDronesNearZone(0, 0, 2)
-	if len(drones) != 3 {
-		t.Error("Wrong number of drones in zone:", len(drones))
+	if drones.count() != 3 {
+		t.Error("Wrong number of drones in zone:", drones.count())
 	}
-	if _, isThere := drones[0]; !isThere {
+	if !drones.contains(0) {
 		t.Error("Wrong drone in the zone", drones)
 	}
-	if _, isThere := drones[1]; !isThere {
+	if !drones.contains(1) {
 		t.Error("Wrong drone in the zone", drones)
 	}
-	if _, isThere := drones[2]; !isThere {
+	if !drones.contains(2) {
 		t.Error("Wrong drone in the zone", drones)
 	}
 }
 
 //Sets up the test reading current status from a certain file
-func setUpTestFromFile(path string, t *testing.T) {
-	if f, err := os.Open(path); err != nil {
+func setUpTestFromFile(path string, t testing.TB) {
+	if f, err := os.Open(filepath.FromSlash(strings.Replace(path, "\\", "/", -1))); err != nil {
 		t.Error("Error opening input file", path)
 	} else {
 		inputReader = f
@@ -273,6 +276,12 @@ func setUpTestFromFile(path string, t *testing.T) {
 	initializeTurnComputation()
 }
 
+//Sets up the benchmark reading current status from a certain file
+func setUpBenchmarkFromFile(path string, b *testing.B) {
+	setUpTestFromFile(path, b)
+	b.ResetTimer()
+}
+
 //Tests method getCentroid
 func TestGetCentroid(t *testing.T) {
 	var testCases = []struct {
@@ -319,10 +328,7 @@ func TestNearestFreeOwnDrone(t *testing.T) {
 //Tests method nearestOwnDroneToGoFromSet
 func TestNearestOwnDroneFromSet(t *testing.T) {
 	setUpTestFromFile(FILE_TESTS_BASE+"NearestFreeOwnDrone\\input.txt", t)
-	set := make(map[int]bool, 2)
-	set[0] = true
-	set[2] = true
-	set[1] = true
+	set := newDroneSet(0, 2, 1)
 	if result, move := nearestOwnDroneToGoFromSet(zones[0].pos, set); result != 0 || !move {
 		t.Error("Nearest unasigned drone:", result)
 	}
@@ -334,7 +340,7 @@ func TestNearestOwnDroneFromSet(t *testing.T) {
 	if result, move := nearestOwnDroneToGoFromSet(zones[0].pos, set); result != 1 || !move {
 		t.Error("Nearest unasigned drone:", result)
 	}
-	delete(set, 0)
+	set.remove(0)
 	if result, move := nearestOwnDroneToGoFromSet(zones[0].pos, set); result != 1 || !move {
 		t.Error("Nearest unasigned drone:", result)
 	}
@@ -386,7 +392,7 @@ func getAssignedDrones() map[int]bool {
 //Tests method availableDistance
 func TestAvailableDistance(t *testing.T) {
 	setUpTestFromFile(FILE_TESTS_BASE+"toCentre\\input.txt", t)
-	if availableDistance(0) != MAX_DISTANCE {
+	if availableDistance(0) != rules.maxDistance {
 		t.Error("All drones should be free as birds")
 	}
 	if isAssigned(0) {
@@ -406,7 +412,7 @@ func TestAvailableDistance(t *testing.T) {
 	if !isAssigned(0) {
 		t.Error("Drone should be completely assigned")
 	}
-	setAvailableDistance(0, MAX_DISTANCE)
+	setAvailableDistance(0, rules.maxDistance)
 	if availableDistance(0) != 0 {
 		t.Error("Available distance cannot be increased")
 	}
@@ -417,7 +423,7 @@ func TestCalculateDonesAvailableDistancesNotMine(t *testing.T) {
 	setUpTestFromFile(FILE_TESTS_BASE+"calculateAvailableDistances\\input2.txt", t)
 	calculateDonesAvailableDistances()
 	for i := 0; i < numDronesPerplayer; i += 1 {
-		if availableDistance(i) != MAX_DISTANCE {
+		if availableDistance(i) != rules.maxDistance {
 			t.Error("Drone", i, "should be free. Zone does not belong to me")
 		}
 	}
@@ -432,16 +438,28 @@ func TestCalculateDonesAvailableDistancesMine(t *testing.T) {
 		t.Error("Drone 0 should stay put because there is an enemy at distance 0", availability)
 	}
 	if availableDistance(1) != 0 {
-		t.Error("Drone 1 should stay put because there is an enemy at distance 1", availability)
+		t.Error("Drone 1 should stay put because the drones in the zone must outnumber the enemy at distance 0", availability)
 	}
-	if availableDistance(2) != 1 {
-		t.Error("Drone 2 should be able to move 1 because there is an enemy at distance 2", availability)
+	if availableDistance(2) != rules.maxDistance {
+		t.Error("Drone 2 should be free: at distance 2 player 1 has 4 drones against our 3, so the zone cannot be held", availability)
 	}
-	if availableDistance(3) != MAX_DISTANCE {
+	if availableDistance(3) != rules.maxDistance {
 		t.Error("Drone 3 should not be constrained, because it is outside the zone", availability)
 	}
 }
 
+//Tests that the drones in a threatened zone are locked before the ones that could come back to it
+func TestCalculateDonesAvailableDistancesNearestFirst(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"fuzz\\nearestGuardsFirst.txt", t)
+	calculateDonesAvailableDistances()
+	inZone := playerDronesNearZone(whoami, 1, 0)
+	for dId := inZone.next(-1); dId >= 0; dId = inZone.next(dId) {
+		if availableDistance(dId) != 0 {
+			t.Error("Drone", dId, "should not leave zone 1. Available distance:", availableDistance(dId))
+		}
+	}
+}
+
 //Tests method maxEnemiesNearZone
 func TestMaxEnemiesNearZone(t *testing.T) {
 	setUpTestFromFile(FILE_TESTS_BASE+"maxEnemyesNearZone\\input.txt", t)
@@ -476,7 +494,7 @@ func TestAttackableByOwner(t *testing.T) {
 //Tests bestAttackToZone when there is no enemy near and all my drones are available
 func TestAttackableWhithNoEnemiesAllAvailable(t *testing.T) {
 	setUpTestFromFile(FILE_TESTS_BASE+"attackable\\inputNoEnemies.txt", t)
-	if a, attackable := bestAttackToZone(0); !attackable || len(a.force) != 1 || !a.force[1] {
+	if a, attackable := bestAttackToZone(0); !attackable || a.force.count() != 1 || !a.force.contains(1) {
 		t.Error("Zone 0 should be attackable by drone 1 alone because it is the nearest one", a)
 	}
 }
@@ -487,7 +505,7 @@ func TestAttackableWhithNoEnemiesUnavailableDrones(t *testing.T) {
 	assignDestinationPoint(0, point{0, 0})
 	assignDestinationPoint(1, point{0, 0})
 	assignDestinationPoint(2, point{0, 0})
-	if a, attackable := bestAttackToZone(0); attackable || len(a.force) != 0 {
+	if a, attackable := bestAttackToZone(0); attackable || a.force.count() != 0 {
 		t.Error("Zone 0 should NOT be attackable because no drone is available", a)
 	}
 }
@@ -498,7 +516,7 @@ func TestAttackableWhithNoEnemiesLittleAvailableDrones(t *testing.T) {
 	setAvailableDistance(0, 2)
 	setAvailableDistance(1, 1)
 	setAvailableDistance(2, 3)
-	if a, attackable := bestAttackToZone(0); attackable || len(a.force) != 0 {
+	if a, attackable := bestAttackToZone(0); attackable || a.force.count() != 0 {
 		t.Error("Zone 0 should NOT be attackable because no drone is available enough", a)
 	}
 }
@@ -506,7 +524,7 @@ func TestAttackableWhithNoEnemiesLittleAvailableDrones(t *testing.T) {
 //Tests bestAttackToZone when there is one enemy in the zone
 func TestAttackableWhithOneEnemyInSitu(t *testing.T) {
 	setUpTestFromFile(FILE_TESTS_BASE+"attackable\\inputOneEnemyInSitu.txt", t)
-	if a, attackable := bestAttackToZone(0); !attackable || len(a.force) != 2 || !a.force[0] || !a.force[1] {
+	if a, attackable := bestAttackToZone(0); !attackable || a.force.count() != 2 || !a.force.contains(0) || !a.force.contains(1) {
 		t.Error("Zone 0 should be attackable by drones 0 and 1", a)
 	}
 }
@@ -514,7 +532,7 @@ func TestAttackableWhithOneEnemyInSitu(t *testing.T) {
 //Tests bestAttackToZone when there is one enemy near (distance 2)
 func TestAttackableWhithOneEnemyNear(t *testing.T) {
 	setUpTestFromFile(FILE_TESTS_BASE+"attackable\\inputOneEnemyNear.txt", t)
-	if a, attackable := bestAttackToZone(0); !attackable || len(a.force) != 2 || !a.force[0] || !a.force[1] {
+	if a, attackable := bestAttackToZone(0); !attackable || a.force.count() != 2 || !a.force.contains(0) || !a.force.contains(1) {
 		t.Error("Zone 0 should be attackable by drones 0 and 1", a)
 	}
 }
@@ -524,7 +542,7 @@ func TestAttackableWhithTwoEnemiesAndOwnForcesInSitu(t *testing.T) {
 	setUpTestFromFile(FILE_TESTS_BASE+"attackable\\inputTwoEnemiesWeReinforce.txt", t)
 	assignDestinationPoint(0, point{100, 100})
 	assignDestinationPoint(1, point{100, 100})
-	if a, attackable := bestAttackToZone(0); !attackable || len(a.force) != 3 || !a.force[0] || !a.force[1] || !a.force[2] {
+	if a, attackable := bestAttackToZone(0); !attackable || a.force.count() != 3 || !a.force.contains(0) || !a.force.contains(1) || !a.force.contains(2) {
 		t.Error("Zone 0 should be attackable by three drones", a)
 	}
 }
@@ -534,11 +552,8 @@ func TestAttackDistanceCalculation(t *testing.T) {
 	setUpTestFromFile(FILE_TESTS_BASE+"attackable\\inputAttackDistance.txt", t)
 	var a1, a2, a3 attack
 	a1.target, a2.target, a3.target = 0, 0, 0
-	a1.force, a2.force, a3.force = make(map[int]bool), make(map[int]bool), make(map[int]bool)
-	a2.force[1] = true
-	a3.force[0] = true
-	a3.force[1] = true
-	a3.force[2] = true
+	a2.force = newDroneSet(1)
+	a3.force = newDroneSet(0, 1, 2)
 	a1.calculateLength()
 	a2.calculateLength()
 	a3.calculateLength()
@@ -552,3 +567,326 @@ func TestAttackDistanceCalculation(t *testing.T) {
 		t.Error("Length of attack is not correctly calculated", a3)
 	}
 }
+
+//Tests the ordering of attacks: best score first, then fewer drones, then shorter, then lower zone ID
+func TestAttacksByScore(t *testing.T) {
+	attacks := []attack{
+		{target: 3, distance: 2, force: newDroneSet(0), score: 5},
+		{target: 2, distance: 1, force: newDroneSet(0, 1), score: 5},
+		{target: 1, distance: 2, force: newDroneSet(0), score: 5},
+		{target: 0, distance: 9, force: newDroneSet(0, 1, 2), score: 8},
+		{target: 4, distance: 1, force: newDroneSet(0), score: 5},
+	}
+	sort.Sort(attacksByScore(attacks))
+	expected := []int{0, 4, 1, 3, 2}
+	for i, a := range attacks {
+		if a.target != expected[i] {
+			t.Error("Wrong attack in position", i, "Got", a.target, "Expected", expected[i], attacks)
+		}
+	}
+}
+
+//Tests method expectedPoints
+func TestExpectedPoints(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"attackable\\inputNoEnemies.txt", t)
+	if result := expectedPoints(10); result != rules.MaxTurns-1-10 {
+		t.Error("Wrong expected points:", result)
+	}
+	if result := expectedPoints(rules.MaxTurns); result != 0 {
+		t.Error("No points can be expected after the end of the game:", result)
+	}
+}
+
+//Tests method zonesAtRisk
+func TestZonesAtRisk(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"calculateAvailableDistances\\input2.txt", t)
+	zones[0].owner = whoami
+	if result := zonesAtRisk(newDroneSet(0, 1), 0); result != 0 {
+		t.Error("Zone 0 remains guarded by drone 2", result)
+	}
+	if result := zonesAtRisk(newDroneSet(0, 1), 2); result != 1 {
+		t.Error("Zone 0 can be reached by two enemies of the same player", result)
+	}
+	if result := zonesAtRisk(newDroneSet(0, 1, 2), 0); result != 1 {
+		t.Error("Zone 0 is left alone with an enemy inside", result)
+	}
+	if result := zonesAtRisk(newDroneSet(3), rules.maxDistance); result != 0 {
+		t.Error("Drone 3 does not protect any zone", result)
+	}
+}
+
+//Tests that nearer attacks are preferred when everything else is the same
+func TestAttackEvaluationPrefersNearerZones(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"attackable\\inputNoEnemies.txt", t)
+	near, _ := bestAttackToZone(2)
+	far, _ := bestAttackToZone(0)
+	near.evaluate()
+	far.evaluate()
+	if near.score <= far.score {
+		t.Error("Attack to zone 2 should be better than attack to zone 0", near, far)
+	}
+}
+
+//Tests that the arrival tables give the same numbers as counting the drones near the zones
+func TestCalculateArrivals(t *testing.T) {
+	for _, path := range []string{"maxEnemyesNearZone\\input.txt", "calculateAvailableDistances\\input2.txt", "benchmark\\input4x11x8.txt"} {
+		setUpTestFromFile(FILE_TESTS_BASE+path, t)
+		for zId, _ := range zones {
+			for dist := -1; dist <= rules.maxDistance+1; dist += 1 {
+				expectedMax := 0
+				for pId, _ := range players {
+					expected := 0
+					for dId, d := range players[pId].drones {
+						if turnBasedDistance(d, zones[zId].pos) <= dist {
+							expected++
+							if !playerDronesNearZone(pId, zId, dist).contains(dId) {
+								t.Error(path, "Drone", dId, "of player", pId, "should be at distance", dist, "of zone", zId)
+							}
+						}
+					}
+					if result := playerDronesNearZoneCount(pId, zId, dist); result != expected {
+						t.Error(path, "Wrong number of drones of player", pId, "near zone", zId, "at distance", dist, "Got", result, "Expected", expected)
+					}
+					if pId != whoami && expected > expectedMax {
+						expectedMax = expected
+					}
+				}
+				if result := maxEnemiesNearZone(zId, dist); result != expectedMax {
+					t.Error(path, "Wrong number of enemies near zone", zId, "at distance", dist, "Got", result, "Expected", expectedMax)
+				}
+			}
+		}
+	}
+}
+
+//Returns what the function writes into the standard output
+func captureOutput(f func(), t testing.TB) string {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal("Error creating pipe:", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+	f()
+	w.Close()
+	output, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal("Error reading output:", err)
+	}
+	return string(output)
+}
+
+//Returns the paths of all the test inputs in the format of the game
+func gameInputs(t testing.TB) (result []string) {
+	root := filepath.FromSlash(strings.Replace(FILE_TESTS_BASE, "\\", "/", -1))
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && info.Name() == "replay" {
+			return filepath.SkipDir
+		}
+		if !info.IsDir() && strings.HasSuffix(path, ".txt") {
+			result = append(result, path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal("Error listing test inputs:", err)
+	}
+	return result
+}
+
+//Tests that play gives the same moves every time it is run with the same input, and that nothing panics
+func TestPlayIsDeterministic(t *testing.T) {
+	const NUM_RUNS = 50
+	for _, path := range gameInputs(t) {
+		var expected string
+		for i := 0; i < NUM_RUNS; i += 1 {
+			setUpTestFromFile(path, t)
+			output := captureOutput(play, t)
+			if panicCount != 0 {
+				t.Error(path, "Nothing should panic:", turnNotes)
+				break
+			}
+			if i == 0 {
+				expected = output
+			} else if output != expected {
+				t.Error(path, "Different moves in run", i, "Got", output, "Expected", expected)
+				break
+			}
+		}
+	}
+}
+
+//Tests that the nearest drones of an attack wait for the farthest one so that all of them arrive at the same time
+func TestLaunchAttackSimultaneousArrival(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"attackable\\inputSimultaneousArrival.txt", t)
+	a, attackable := bestAttackToZone(0)
+	if !attackable || a.force != newDroneSet(0, 1) || a.distance != 5 {
+		t.Fatal("Zone 0 should be attackable by drones 0 and 1 in 5 turns", a)
+	}
+	launchAttack(a)
+	if nextMove[0] != players[whoami].drones[0] {
+		t.Error("Drone 0 should wait for drone 1", nextMove)
+	}
+	if nextMove[1] != zones[0].pos {
+		t.Error("Drone 1 should go to the zone", nextMove)
+	}
+	if len(attackPlans) != 1 || attackPlans[0].arrival != turn+5 {
+		t.Error("Attack should arrive in 5 turns", attackPlans)
+	}
+}
+
+//Tests that an attack is re-timed when one of its drones is delayed
+func TestLaunchAttackRetiming(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"attackable\\inputSimultaneousArrival.txt", t)
+	strategyAttack()
+	arrival := attackPlans[0].arrival
+	turn++ //Drone 1 has not moved
+	initializeTurnComputation()
+	strategyAttack()
+	if len(attackPlans) != 1 || attackPlans[0].arrival != arrival+1 {
+		t.Fatal("Attack should be delayed one turn", attackPlans)
+	}
+	retimed := false
+	for _, note := range turnNotes {
+		retimed = retimed || strings.Contains(note, "re-timed")
+	}
+	if !retimed {
+		t.Error("Re-timing should be informed", turnNotes)
+	}
+	players[whoami].drones[1] = point{1000, 1500} //Drone 1 moves as expected
+	turn++
+	initializeTurnComputation()
+	strategyAttack()
+	if len(attackPlans) != 1 || attackPlans[0].arrival != arrival+1 {
+		t.Error("Attack should keep its arrival", attackPlans)
+	}
+}
+
+//Tests that attacks are cancelled and their drones regrouped when the enemy reinforces the zone
+func TestRegroupFromHopelessAttacks(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"attackable\\inputSimultaneousArrival.txt", t)
+	strategyAttack()
+	turn++
+	players[1].drones[1] = point{1050, 1000} //The enemy reinforces the zone
+	initializeTurnComputation()
+	strategyRegroupFromHopelessAttacks()
+	if numAssignedDrones() != 2 || nextMove[0] != nextMove[1] {
+		t.Error("Both drones should regroup in the same point", nextMove)
+	}
+	if nextMove[0] == zones[0].pos || influence.enemyReach[cellOf(nextMove[0])] < SAFE_TURNS {
+		t.Error("Drones should regroup out of the reach of the enemies", nextMove)
+	}
+	cancelled := false
+	for _, note := range turnNotes {
+		cancelled = cancelled || strings.Contains(note, "cancelled")
+	}
+	if !cancelled {
+		t.Error("Cancellation should be informed", turnNotes)
+	}
+}
+
+//Tests that attacks that still can succeed are not cancelled
+func TestRegroupFromHopelessAttacksNotHopeless(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"attackable\\inputSimultaneousArrival.txt", t)
+	strategyAttack()
+	turn++
+	initializeTurnComputation()
+	strategyRegroupFromHopelessAttacks()
+	if numAssignedDrones() != 0 {
+		t.Error("No drone should regroup", nextMove)
+	}
+}
+
+//Sets up the next turn of the test moving the given drone of the given player
+func moveDroneForNextTurn(pId, dId int, p point) {
+	rememberPositions()
+	players[pId].drones[dId] = p
+	turn++
+	initializeTurnComputation()
+}
+
+//Tests that a zone whose owner is leaving is captured when it becomes empty
+func TestCaptureVacatedZones(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"captureVacated\\input.txt", t)
+	strategyCaptureVacatedZones()
+	if numAssignedDrones() != 0 {
+		t.Error("Nothing can be known in the first turn", nextMove)
+	}
+	moveDroneForNextTurn(1, 0, point{1050, 1000})
+	if result := turnsToBeVacated(0); result != 2 {
+		t.Error("Zone 0 should be empty in 2 turns. Got", result)
+	}
+	strategyCaptureVacatedZones()
+	if nextMove[0] != zones[0].pos || isAssigned(1) {
+		t.Error("Drone 0 should go to zone 0", nextMove)
+	}
+}
+
+//Tests that the nearest drone waits if the zone is not going to be empty when it arrives
+func TestCaptureVacatedZonesWaiting(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"captureVacated\\input.txt", t)
+	moveDroneForNextTurn(1, 0, point{1010, 1000})
+	if result := turnsToBeVacated(0); result != 10 {
+		t.Error("Zone 0 should be empty in 10 turns. Got", result)
+	}
+	strategyCaptureVacatedZones()
+	if nextMove[0] != players[whoami].drones[0] {
+		t.Error("Drone 0 should wait", nextMove)
+	}
+}
+
+//Tests that zones whose owner stays are not targeted
+func TestCaptureVacatedZonesOwnerStays(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"captureVacated\\input.txt", t)
+	moveDroneForNextTurn(1, 1, point{300, 300})
+	if result := turnsToBeVacated(0); result != -1 {
+		t.Error("Zone 0 is not being vacated. Got", result)
+	}
+	strategyCaptureVacatedZones()
+	if numAssignedDrones() != 0 {
+		t.Error("No drone should move", nextMove)
+	}
+}
+
+//Tests that zones are not targeted when a drone of the owner arrives before the last one leaves
+func TestCaptureVacatedZonesReinforced(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"captureVacated\\input.txt", t)
+	rememberPositions()
+	players[1].drones[0] = point{1010, 1000} //Leaving in 10 turns
+	players[1].drones[1] = point{300, 300}   //Arriving in 9 turns
+	turn++
+	initializeTurnComputation()
+	if result := turnsToBeVacated(0); result != -1 {
+		t.Error("Zone 0 is being reinforced. Got", result)
+	}
+	strategyCaptureVacatedZones()
+	if numAssignedDrones() != 0 {
+		t.Error("No drone should move", nextMove)
+	}
+	players[1].drones[0] = point{1050, 1000} //Leaving in 2 turns, before the other one arrives
+	initializeTurnComputation()
+	if result := turnsToBeVacated(0); result != 2 {
+		t.Error("Zone 0 should be empty in 2 turns. Got", result)
+	}
+}
+
+//Tests that the drones of an attack that wait do it out of the reach of the enemies
+func TestStagingPoint(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"attackable\\inputSimultaneousArrival.txt", t)
+	if p := stagingPoint(0, 0); p != players[whoami].drones[0] {
+		t.Error("Drone 0 should wait where it is, out of the reach of the enemies. Got", p)
+	}
+	players[1].drones[1] = point{1000, 1350} //An enemy next to drone 0
+	initializeTurnComputation()
+	if p := stagingPoint(0, 0); p != (point{1000, 1150}) {
+		t.Error("Drone 0 should wait one turn nearer the zone. Got", p)
+	}
+	zones = append(zones, zone{point{1000, 1150}, 1}) //That point is in a zone I do not own
+	if p := stagingPoint(0, 0); p != players[whoami].drones[0] {
+		t.Error("Drone 0 should not wait in other zones. Got", p)
+	}
+}
@@ -0,0 +1,118 @@
+//Participating Game of Drones by CodinGame - Influence map of the board
+package main
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	INFLUENCE_CELL_SIZE = 100  //Side of each of the square cells the board is divided into
+	SAFE_TURNS          = 2    //Minimum number of turns an enemy must need to reach a cell to station a drone there
+	STATION_TRAVEL_COST = 0.01 //Penalty per turn a drone needs to reach the cell where it is stationed
+)
+
+var ( //influence map-related variables
+	influenceCols     int     //Number of columns of cells
+	influenceRows     int     //Number of rows of cells
+	cellZoneDistances [][]int //Number of turns from the centre of each cell to each zone. Calculated once per game
+	influence         struct {
+		own        []float64 //Influence of my drones over each cell
+		enemy      []float64 //Influence of the enemy drones over each cell
+		enemyReach []int     //Turns the nearest enemy drone needs to reach each cell
+		stationed  []int     //Number of my drones sent to each cell in this turn
+	}
+)
+
+/* CONSTANTS AND VARIABLES END ******************************************************************* INFLUENCE MAP BEGIN */
+
+//Divides the board into cells and calculates the distances from each cell to each zone
+func initializeInfluenceMap() {
+	influenceCols = (rules.Width + INFLUENCE_CELL_SIZE - 1) / INFLUENCE_CELL_SIZE
+	influenceRows = (rules.Height + INFLUENCE_CELL_SIZE - 1) / INFLUENCE_CELL_SIZE
+	numCells := influenceCols * influenceRows
+	cellZoneDistances = make([][]int, numCells)
+	for cId := 0; cId < numCells; cId += 1 {
+		cellZoneDistances[cId] = make([]int, numZones)
+		for zId, z := range zones {
+			cellZoneDistances[cId][zId] = turnBasedDistance(cellCentre(cId), z.pos)
+		}
+	}
+	influence.own = make([]float64, numCells)
+	influence.enemy = make([]float64, numCells)
+	influence.enemyReach = make([]int, numCells)
+	influence.stationed = make([]int, numCells)
+}
+
+//Recalculates the influence of every drone over every cell. Each drone adds 1/(1+turns to reach the cell)
+func updateInfluenceMap() {
+	for cId, _ := range influence.own {
+		centre := cellCentre(cId)
+		influence.own[cId], influence.enemy[cId], influence.enemyReach[cId] = 0, 0, rules.maxDistance
+		influence.stationed[cId] = 0
+		for pId, p := range players {
+			for _, d := range p.drones {
+				dist := turnBasedDistance(d, centre)
+				if pId == whoami {
+					influence.own[cId] += 1.0 / float64(1+dist)
+					continue
+				}
+				influence.enemy[cId] += 1.0 / float64(1+dist)
+				if dist < influence.enemyReach[cId] {
+					influence.enemyReach[cId] = dist
+				}
+			}
+		}
+	}
+}
+
+//Returns the ID of the cell that contains the given point. Points outside the board belong to the nearest cell
+func cellOf(p point) int {
+	col, row := p.x/INFLUENCE_CELL_SIZE, p.y/INFLUENCE_CELL_SIZE
+	col = maxInt(0, minInt(influenceCols-1, col))
+	row = maxInt(0, minInt(influenceRows-1, row))
+	return row*influenceCols + col
+}
+
+//Returns the centre of the given cell
+func cellCentre(cId int) point {
+	return point{(cId%influenceCols)*INFLUENCE_CELL_SIZE + INFLUENCE_CELL_SIZE/2,
+		(cId/influenceCols)*INFLUENCE_CELL_SIZE + INFLUENCE_CELL_SIZE/2}
+}
+
+//Returns true iff the zone is worth being near to: the frontier analysis says it is contested
+func isContested(zId int) bool {
+	return frontier[zId] == CONTESTED
+}
+
+//Returns the centre of the best cell to station the given drone: the one that maximizes the reach to contested zones
+//while staying out of the reach of the enemies. If every cell is within their reach, the safest one is returned
+func bestStationPoint(dId int) point {
+	contested := make([]int, 0, numZones)
+	for zId, _ := range zones {
+		if isContested(zId) {
+			contested = append(contested, zId)
+		}
+	}
+	bestCell, bestScore, bestReach := -1, 0.0, -1
+	for cId, _ := range cellZoneDistances {
+		reach := minInt(influence.enemyReach[cId], SAFE_TURNS)
+		score := stationScore(cId, contested) / float64(1+influence.stationed[cId])
+		score -= STATION_TRAVEL_COST * float64(turnBasedDistance(players[whoami].drones[dId], cellCentre(cId)))
+		if reach > bestReach || (reach == bestReach && score > bestScore) {
+			bestCell, bestScore, bestReach = cId, score, reach
+		}
+	}
+	return cellCentre(bestCell)
+}
+
+//Returns how well the given cell reaches the given zones. Each zone adds its value divided by 1+turns to reach it
+func stationScore(cId int, zIds []int) (result float64) {
+	for _, zId := range zIds {
+		result += (1 + zoneValue(zId)) / float64(1+cellZoneDistances[cId][zId])
+	}
+	return result
+}
+
+//Marks the cell that contains the point as the station of one more drone
+func addStationedDrone(p point) {
+	influence.stationed[cellOf(p)]++
+}
+
+/* INFLUENCE MAP END */
@@ -0,0 +1,69 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"testing"
+)
+
+//Tests methods cellOf and cellCentre
+func TestCellOf(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	var testCases = []struct {
+		in  point
+		out int
+	}{
+		{point{0, 0}, 0},
+		{point{150, 250}, 2*influenceCols + 1},
+		{point{rules.Width - 1, rules.Height - 1}, influenceCols*influenceRows - 1},
+		{point{rules.Width + 500, -10}, influenceCols - 1}, //outside the board
+	}
+	for i, testCase := range testCases {
+		if result := cellOf(testCase.in); testCase.out != result {
+			t.Error("Error in item", i, "Got", result, "Expected", testCase.out, "Case:", testCase)
+		}
+	}
+	if result := cellCentre(2*influenceCols + 1); result != (point{150, 250}) {
+		t.Error("Wrong centre of the cell:", result)
+	}
+}
+
+//Tests method updateInfluenceMap
+func TestUpdateInfluenceMap(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	enemyCell, ownCell := cellOf(point{3500, 1500}), cellOf(point{500, 500})
+	if influence.enemyReach[enemyCell] != 0 {
+		t.Error("Enemy drone should be in the cell", influence.enemyReach[enemyCell])
+	}
+	if influence.enemyReach[ownCell] != 29 {
+		t.Error("Enemy drones should need 29 turns to reach the cell of my drone", influence.enemyReach[ownCell])
+	}
+	if influence.own[ownCell] <= influence.enemy[ownCell] || influence.own[enemyCell] >= influence.enemy[enemyCell] {
+		t.Error("Each player should have more influence near its drones", influence.own[ownCell], influence.enemy[ownCell],
+			influence.own[enemyCell], influence.enemy[enemyCell])
+	}
+}
+
+//Tests that drones are stationed out of the reach of the enemies and near the cluster of zones
+func TestBestStationPoint(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	p := bestStationPoint(0)
+	if influence.enemyReach[cellOf(p)] < SAFE_TURNS {
+		t.Error("Drone should not be stationed in the reach of the enemies", p)
+	}
+	if turnBasedDistance(p, zones[3].pos) >= turnBasedDistance(p, zones[2].pos) {
+		t.Error("Drone should be stationed near the cluster of zones", p)
+	}
+}
+
+//Tests that strategyStationFreeDrones gives a destination to every free drone
+func TestStrategyStationFreeDrones(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	assignDestinationZone(0, 0, "I say so")
+	strategyStationFreeDrones()
+	if numAssignedDrones() != numDronesPerplayer {
+		t.Error("All drones should be assigned", availability)
+	}
+	if nextMove[0] != zones[0].pos {
+		t.Error("Assigned drones should not be stationed", nextMove)
+	}
+}
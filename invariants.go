@@ -0,0 +1,85 @@
+//Participating Game of Drones by CodinGame - Invariants of the moves of each turn
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	INVARIANT_NOTE_PREFIX = "INVARIANT" //Start of the notes of the turn that report a broken invariant
+)
+
+var strictInvariants bool //True iff broken invariants must panic instead of being corrected (the tests set it)
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* INVARIANTS BEGIN */
+
+//Checks the moves of the turn before they are printed. Broken invariants are corrected and noted in the turn, or panic
+//if strictInvariants is set
+func enforceMoveInvariants() {
+	violations := correctMoves()
+	if len(violations) == 0 {
+		return
+	}
+	if strictInvariants {
+		panic(fmt.Sprint("broken invariants in turn ", turn, ": ", strings.Join(violations, "; ")))
+	}
+	for _, v := range violations {
+		turnInfo(INVARIANT_NOTE_PREFIX, v)
+	}
+}
+
+//Corrects the moves of the turn and returns the broken invariants:
+//- There is a move per drone. Missing ones are added and extra ones are removed
+//- Every drone has been assigned a destination. Drones without one stay where they are
+//- Every destination is inside the board. Destinations outside are moved to the nearest point of the board
+//- No zone I hold is abandoned when the enemies would take it next turn. Leaving drones are sent back, lowest ID first
+func correctMoves() (violations []string) {
+	if len(nextMove) != numDronesPerplayer {
+		violations = append(violations, fmt.Sprint(len(nextMove), " moves for ", numDronesPerplayer, " drones"))
+		moves := noMoves(numDronesPerplayer)
+		copy(moves, nextMove)
+		nextMove = moves
+	}
+	for dId, d := range players[whoami].drones {
+		if nextMove[dId] == NO_MOVE {
+			violations = append(violations, fmt.Sprint("drone ", dId, " has no destination"))
+			nextMove[dId] = d
+		}
+		if inside := clampToBoard(nextMove[dId]); inside != nextMove[dId] {
+			violations = append(violations, fmt.Sprint("drone ", dId, " goes out of the board to ", nextMove[dId]))
+			nextMove[dId] = inside
+		}
+	}
+	for zId, z := range zones {
+		if z.owner != whoami {
+			continue
+		}
+		inZone := playerDronesNearZone(whoami, zId, 0)
+		need := maxEnemiesNearZone(zId, 1)
+		staying := dronesStayingInZone(zId, inZone)
+		if staying.count() >= need || inZone.count() < need {
+			continue
+		}
+		violations = append(violations, fmt.Sprint("zone ", zId, " is abandoned: ", staying.count(), " drones stay against ", need, " enemies"))
+		leaving := inZone.difference(staying)
+		for dId := leaving.next(-1); dId >= 0 && staying.count() < need; dId = leaving.next(dId) {
+			nextMove[dId] = z.pos
+			staying.add(dId)
+		}
+	}
+	return violations
+}
+
+//Returns the given drones (that are in the zone) whose destination is in the zone too
+func dronesStayingInZone(zId int, inZone droneSet) (result droneSet) {
+	for dId := inZone.next(-1); dId >= 0; dId = inZone.next(dId) {
+		if turnBasedDistance(nextMove[dId], zones[zId].pos) == 0 {
+			result.add(dId)
+		}
+	}
+	return result
+}
+
+/* INVARIANTS END */
@@ -0,0 +1,73 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+//Runs the tests with strict invariants: broken invariants panic instead of being corrected
+func TestMain(m *testing.M) {
+	strictInvariants = true
+	os.Exit(m.Run())
+}
+
+//Tests that wrong numbers of moves, drones without destination and destinations out of the board are corrected
+func TestCorrectMoves(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	nextMove = []point{{-10, 2000}}
+	violations := correctMoves()
+	if len(violations) != 3 {
+		t.Error("Wrong violations:", violations)
+	}
+	expected := []point{{0, rules.Height - 1}, players[whoami].drones[1]}
+	for dId, m := range expected {
+		if nextMove[dId] != m {
+			t.Error("Error in item", dId, "Got", nextMove[dId], "Expected", m)
+		}
+	}
+	if violations := correctMoves(); len(violations) != 0 {
+		t.Error("Corrected moves should not break invariants:", violations)
+	}
+}
+
+//Tests that drones leaving a zone the enemy would take next turn are sent back, and only as many as needed
+func TestCorrectMovesAbandonedZone(t *testing.T) {
+	setBoard(testBotBoard)
+	setTurn(botTurn{[]int{0, 1}, [][][2]int{{{500, 500}, {520, 500}}, {{500, 620}, {3000, 1500}}}})
+	initializeTurnComputation()
+	nextMove = []point{{3500, 1300}, {3500, 1300}}
+	violations := correctMoves()
+	if len(violations) != 1 || nextMove[0] != zones[0].pos || nextMove[1] != (point{3500, 1300}) {
+		t.Error("Drone 0 should go back to the zone", violations, nextMove)
+	}
+	players[1].drones[0] = point{3500, 1000}
+	initializeTurnComputation()
+	nextMove = []point{{3500, 1300}, {3500, 1300}}
+	if violations := correctMoves(); len(violations) != 0 {
+		t.Error("Zones without threats may be left:", violations)
+	}
+}
+
+//Tests that broken invariants panic in strict mode and are noted in the turn otherwise
+func TestEnforceMoveInvariants(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	nextMove = noMoves(numDronesPerplayer)
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Broken invariants should panic in strict mode")
+			}
+		}()
+		enforceMoveInvariants()
+	}()
+	strictInvariants = false
+	defer func() { strictInvariants = true }()
+	nextMove = noMoves(numDronesPerplayer)
+	notes := len(turnNotes)
+	enforceMoveInvariants()
+	if len(turnNotes) != notes+numDronesPerplayer || !strings.HasPrefix(turnNotes[notes], INVARIANT_NOTE_PREFIX) {
+		t.Error("Broken invariants should be noted:", turnNotes[notes:])
+	}
+}
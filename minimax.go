@@ -0,0 +1,263 @@
+//Participating Game of Drones by CodinGame - Paranoid minimax search of attacks
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	SEARCH_MAX_DEPTH  = 6  //Maximum number of rounds (each player chooses an action, then the model advances) searched
+	SEARCH_STEP_TURNS = 4  //Number of turns the forward model advances after each round
+	SEARCH_MILLIS     = 40 //Milliseconds of the turn the search can use
+)
+
+var ( //search-related variables
+	minimaxAttacks bool                               //True iff strategyMinimax decides the attacks (see playOptions)
+	searchBudget   = SEARCH_MILLIS * time.Millisecond //Time of the turn the search can use
+)
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* DATA TYPES BEGIN */
+
+//Coarse action of a player: the drones of the force go to the centre of the zone. zone -1 means nothing changes
+type searchAction struct {
+	zone  int      //ID of the target zone, or -1
+	force droneSet //Drones sent to the zone
+}
+
+//Status of a search
+type search struct {
+	deadline  time.Time  //When the search must stop
+	aborted   bool       //True iff the deadline was reached
+	nodes     int        //Number of nodes visited
+	order     []int      //Players in the order they choose their actions. I am the first one
+	movable   []droneSet //Drones each player can move. Array index = player's ID
+	available []int      //Available distance of each of my drones (see availableDistance). nil if there is no limit
+}
+
+/* DATA TYPES END ******************************************************************** MINIMAX BEGIN */
+
+//Calculates the movements for unasigned drones based on the following strategy:
+//- A paranoid minimax search (all the enemies play against me) chooses the zone to attack and the drones to send
+//- The drones of the chosen action attack the zone (see launchAttack). If staying is better, nothing is done
+func strategyMinimax() {
+	action, depth := bestSearchAction(SEARCH_MAX_DEPTH, searchBudget)
+	trace("Minimax action", action, "depth", depth)
+	if action.zone < 0 {
+		return
+	}
+	a := attack{target: action.zone, force: action.force}
+	for dId := a.force.next(-1); dId >= 0; dId = a.force.next(dId) {
+		a.distance = maxInt(a.distance, distances[whoami][dId][a.target])
+	}
+	turnInfo("Minimax (depth", depth, ") attacks zone", a.target, "with", a.force)
+	launchAttack(a)
+}
+
+//Chooses the attacks with strategyMinimax or strategyAttack depending on minimaxAttacks
+func strategyChosenAttack() {
+	if minimaxAttacks {
+		strategyMinimax()
+	} else {
+		strategyAttack()
+	}
+}
+
+//Returns my best action found by iterative deepening up to maxDepth rounds within the budget, and the depth of the
+//deepest search completed. If not even depth 1 is completed, the action does nothing
+func bestSearchAction(maxDepth int, budget time.Duration) (result searchAction, depth int) {
+	sr := newSearch(budget)
+	root := currentSimState()
+	result.zone = -1
+	for d := 1; d <= maxDepth; d += 1 {
+		action, _ := sr.root(root, d)
+		if sr.aborted {
+			break
+		}
+		result, depth = action, d
+	}
+	trace("Search visited", sr.nodes, "nodes")
+	return result, depth
+}
+
+//Returns a search that ends after the budget. My unassigned drones that can leave their zones (see availableDistance)
+//and every enemy drone can be moved
+func newSearch(budget time.Duration) *search {
+	sr := &search{deadline: time.Now().Add(budget), order: []int{whoami}, movable: make([]droneSet, numPlayers),
+		available: make([]int, numDronesPerplayer)}
+	for dId, _ := range sr.available {
+		sr.available[dId] = availableDistance(dId)
+	}
+	for pId, _ := range players {
+		if pId != whoami {
+			sr.order = append(sr.order, pId)
+		}
+		for dId := 0; dId < numDronesPerplayer; dId += 1 {
+			if pId != whoami || (!isAssigned(dId) && sr.available[dId] > 0) {
+				sr.movable[pId].add(dId)
+			}
+		}
+	}
+	return sr
+}
+
+//Returns my best action in the state searching depth rounds, and its value
+func (sr *search) root(s simState, depth int) (best searchAction, bestValue float64) {
+	bestValue = math.Inf(-1)
+	for _, a := range sr.actions(s, whoami) {
+		child := s.clone()
+		child.apply(whoami, a)
+		v := sr.value(child, depth, 1, bestValue, math.Inf(1))
+		if sr.aborted {
+			return best, bestValue
+		}
+		if v > bestValue {
+			best, bestValue = a, v
+		}
+	}
+	return best, bestValue
+}
+
+//Returns the value of the state for me when the player order[ply] has to choose its action and depth rounds are left.
+//I maximize it and the enemies minimize it. Branches that cannot change the result are pruned (alpha-beta)
+func (sr *search) value(s simState, depth, ply int, alpha, beta float64) float64 {
+	sr.nodes++
+	if time.Now().After(sr.deadline) {
+		sr.aborted = true
+		return 0
+	}
+	if ply == len(sr.order) {
+		s.advance(SEARCH_STEP_TURNS)
+		depth, ply = depth-1, 0
+	}
+	if depth == 0 || s.turn >= rules.MaxTurns {
+		return evaluateState(s, whoami, evaluationWeights)
+	}
+	pId := sr.order[ply]
+	maximizing := pId == whoami
+	best := math.Inf(1)
+	if maximizing {
+		best = math.Inf(-1)
+	}
+	for _, a := range sr.actions(s, pId) {
+		child := s.clone()
+		child.apply(pId, a)
+		v := sr.value(child, depth, ply+1, alpha, beta)
+		if sr.aborted {
+			return 0
+		}
+		if maximizing {
+			best, alpha = math.Max(best, v), math.Max(alpha, v)
+		} else {
+			best, beta = math.Min(best, v), math.Min(beta, v)
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return best
+}
+
+//Returns the coarse actions of the player: doing nothing, and, for each zone, sending the nearest drones it needs to
+//outnumber (or equal, if it owns the zone) the drones of the strongest other player in or heading to the zone
+func (sr *search) actions(s simState, pId int) []searchAction {
+	result := []searchAction{{-1, 0}}
+	for zId, z := range zones {
+		rivals := 0
+		for other, _ := range s.drones {
+			if other != pId {
+				rivals = maxInt(rivals, s.dronesForZone(other, zId))
+			}
+		}
+		need := rivals
+		if s.owners[zId] != pId {
+			need++
+		}
+		if need == 0 {
+			continue
+		}
+		force := s.nearestDrones(pId, z.pos, need, sr.candidates(s, pId, zId))
+		if force.count() < need {
+			continue
+		}
+		if force.difference(s.headingTo(pId, z.pos)) == 0 {
+			continue //Nothing would change
+		}
+		result = append(result, searchAction{zId, force})
+	}
+	return result
+}
+
+//Returns the drones of the player that can be sent to the zone: the movable ones, and, if they are mine and their
+//available distance is limited, only the ones whose available distance reaches the zone
+func (sr *search) candidates(s simState, pId, zId int) (result droneSet) {
+	result = sr.movable[pId]
+	if pId != whoami || sr.available == nil {
+		return result
+	}
+	for dId := result.next(-1); dId >= 0; dId = result.next(dId) {
+		if turnBasedDistance(s.drones[pId][dId], zones[zId].pos) > sr.available[dId] {
+			result.remove(dId)
+		}
+	}
+	return result
+}
+
+//Sends the drones of the action to its zone
+func (s *simState) apply(pId int, a searchAction) {
+	if a.zone < 0 {
+		return
+	}
+	for dId := a.force.next(-1); dId >= 0; dId = a.force.next(dId) {
+		s.targets[pId][dId] = zones[a.zone].pos
+	}
+}
+
+//Returns the number of drones of the player that are in the zone or heading to it
+func (s simState) dronesForZone(pId, zId int) (result int) {
+	for dId, d := range s.drones[pId] {
+		if s.targets[pId][dId] == zones[zId].pos || euclideanDistance(d, zones[zId].pos) <= rules.ZoneRadius {
+			result++
+		}
+	}
+	return result
+}
+
+//Returns the drones of the player whose target is the given point
+func (s simState) headingTo(pId int, p point) (result droneSet) {
+	for dId, target := range s.targets[pId] {
+		if target == p {
+			result.add(dId)
+		}
+	}
+	return result
+}
+
+//Returns up to n of the given drones of the player, the nearest to the point first (lowest ID in case of tie)
+func (s simState) nearestDrones(pId int, p point, n int, candidates droneSet) (result droneSet) {
+	for result.count() < n {
+		best, bestDist := -1, 0.0
+		for dId := candidates.next(-1); dId >= 0; dId = candidates.next(dId) {
+			if dist := euclideanDistance(s.drones[pId][dId], p); !result.contains(dId) && (best == -1 || dist < bestDist) {
+				best, bestDist = dId, dist
+			}
+		}
+		if best == -1 {
+			break
+		}
+		result.add(best)
+	}
+	return result
+}
+
+//Returns the action as text
+func (a searchAction) String() string {
+	if a.zone < 0 {
+		return "stay"
+	}
+	return fmt.Sprint("zone ", a.zone, " with ", a.force)
+}
+
+/* MINIMAX END */
@@ -0,0 +1,67 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+//Tests that the search sends a single drone to the unguarded zone and not both of them
+func TestBestSearchAction(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	action, depth := bestSearchAction(3, time.Minute)
+	if depth != 3 {
+		t.Error("The search should have completed depth 3. Got", depth)
+	}
+	if action.zone != 0 || action.force != newDroneSet(0) {
+		t.Error("Drone 0 should go to zone 0. Got", action)
+	}
+}
+
+//Tests that the search does nothing if it has no time
+func TestBestSearchActionNoTime(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	if action, depth := bestSearchAction(3, 0); depth != 0 || action.zone != -1 {
+		t.Error("Nothing should be done without time. Got", action, depth)
+	}
+}
+
+//Tests that the actions take into account the enemy drones heading to the zones
+func TestSearchActions(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	sr := newSearch(time.Minute)
+	s := currentSimState()
+	s.targets[1][1] = zones[0].pos
+	for _, a := range sr.actions(s, whoami) {
+		if a.zone == 0 && a.force.count() != 2 {
+			t.Error("Zone 0 needs 2 drones once the enemy heads to it. Got", a)
+		}
+	}
+}
+
+//Tests that strategyMinimax launches the attack it finds
+func TestStrategyMinimax(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	calculateDonesAvailableDistances()
+	strategyMinimax()
+	if nextMove[0] != zones[0].pos || isAssigned(1) {
+		t.Error("Drone 0 should attack zone 0", nextMove)
+	}
+	if len(attackPlans) != 1 || attackPlans[0].target != 0 {
+		t.Error("The attack should be planned", attackPlans)
+	}
+}
+
+//Tests that the search does not move the drones that must guard their zones
+func TestSearchAvailability(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	availability.drones[0] = 0 //Drone 0 cannot leave
+	action, _ := bestSearchAction(3, time.Minute)
+	if action.force.contains(0) {
+		t.Error("Drone 0 should not be moved. Got", action)
+	}
+	availability.drones[1] = 2 //Drone 1 cannot reach zone 0 either
+	if action, _ = bestSearchAction(3, time.Minute); action.zone == 0 {
+		t.Error("Zone 0 cannot be reached. Got", action)
+	}
+}
@@ -0,0 +1,174 @@
+//Participating Game of Drones by CodinGame - Rally points of the idle drones
+package main
+
+import (
+	"math"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	MAX_RALLY_POINTS    = 3   //Maximum number of rally points
+	RALLY_THREAT_WEIGHT = 1.0 //Extra weight of the contested zones when choosing the rally points
+)
+
+var ( //rally point-related variables. Recalculated when the owners or the frontier of the zones change
+	rallyCells  []int //Cells of the influence map chosen as rally points
+	rallyOwners []int //Owner of each zone when the rally points were calculated
+	rallyStatus []int //Frontier status of each zone when the rally points were calculated
+)
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* RALLY POINTS BEGIN */
+
+//Returns the rally points of the current turn, recalculating them if the owners or the frontier of the zones changed
+func rallyPoints() []point {
+	if rallyCells == nil || !sameInts(rallyOwners, zoneOwners()) || !sameInts(rallyStatus, frontier) {
+		rallyOwners, rallyStatus = zoneOwners(), append([]int(nil), frontier...)
+		weights := rallyWeights()
+		rallyCells = solveRallyCells(weights, numRallyPoints(weights))
+		trace("Rally cells", rallyCells, "weights", weights)
+	}
+	result := make([]point, len(rallyCells))
+	for i, cId := range rallyCells {
+		result[i] = cellCentre(cId)
+	}
+	return result
+}
+
+//Returns the rally point nearest to the given drone of mine
+func nearestRallyPoint(dId int) point {
+	var result point
+	minDist := -1
+	for _, rp := range rallyPoints() {
+		if dist := turnBasedDistance(players[whoami].drones[dId], rp); dist < minDist || minDist == -1 {
+			result, minDist = rp, dist
+		}
+	}
+	return result
+}
+
+//Returns where the given drone of mine regroups: its nearest rally point if it is out of the reach of the enemies
+//(up to SAFE_TURNS) or the safest cell nearest to it otherwise
+func regroupRallyPoint(dId int) point {
+	rp := nearestRallyPoint(dId)
+	bestCell, bestReach, bestDist := cellOf(rp), minInt(influence.enemyReach[cellOf(rp)], SAFE_TURNS), 0
+	for cId, _ := range cellZoneDistances {
+		reach, dist := minInt(influence.enemyReach[cId], SAFE_TURNS), turnBasedDistance(cellCentre(cId), rp)
+		if reach > bestReach || (reach == bestReach && dist < bestDist) {
+			bestCell, bestReach, bestDist = cId, reach, dist
+		}
+	}
+	if bestCell == cellOf(rp) {
+		return rp
+	}
+	return cellCentre(bestCell)
+}
+
+//Returns how much each zone matters when choosing the rally points: 0 for the zones safely mine, and its value plus
+//RALLY_THREAT_WEIGHT if it is contested for the rest. If every zone is safely mine, all of them matter by their value
+func rallyWeights() []float64 {
+	result := make([]float64, numZones)
+	cared := false
+	for zId, _ := range zones {
+		switch frontier[zId] {
+		case SAFELY_THEIRS:
+			result[zId] = 1 + zoneValue(zId)
+		case CONTESTED:
+			result[zId] = 1 + zoneValue(zId) + RALLY_THREAT_WEIGHT
+		}
+		cared = cared || result[zId] > 0
+	}
+	if !cared {
+		for zId, _ := range zones {
+			result[zId] = 1 + zoneValue(zId)
+		}
+	}
+	return result
+}
+
+//Returns the number of rally points: one per cluster with zones that matter, up to MAX_RALLY_POINTS
+func numRallyPoints(weights []float64) int {
+	clusters := make(map[int]bool)
+	for zId, w := range weights {
+		if w > 0 {
+			clusters[zoneInfos[zId].cluster] = true
+		}
+	}
+	return maxInt(1, minInt(len(clusters), MAX_RALLY_POINTS))
+}
+
+//Returns k cells that approximately minimize the weighted worst-case turns from a zone to its nearest cell
+//(weighted k-centre). Cells are added greedily, each one the best given the previous ones, and then each cell is
+//replaced by the best alternative while that improves the solution. Ties are broken by the weighted total turns
+func solveRallyCells(weights []float64, k int) []int {
+	result := make([]int, 0, k)
+	for len(result) < k {
+		result = append(result, -1)
+		result[len(result)-1] = bestRallyCell(result, len(result)-1, weights)
+	}
+	for improved := true; improved; {
+		improved = false
+		for i, cId := range result {
+			if result[i] = bestRallyCell(result, i, weights); result[i] != cId {
+				improved = true
+			}
+		}
+	}
+	return result
+}
+
+//Returns the best cell for the position i of the rally cells given the rest of them. The current one is kept if no
+//other cell is strictly better
+func bestRallyCell(cells []int, i int, weights []float64) int {
+	bestCell := cells[i]
+	bestWorst, bestTotal := math.Inf(1), math.Inf(1)
+	if bestCell >= 0 {
+		bestWorst, bestTotal = rallyCost(cells, weights)
+	}
+	for cId, _ := range cellZoneDistances {
+		cells[i] = cId
+		worst, total := rallyCost(cells, weights)
+		if worst < bestWorst || (worst == bestWorst && total < bestTotal) {
+			bestCell, bestWorst, bestTotal = cId, worst, total
+		}
+	}
+	cells[i] = bestCell
+	return bestCell
+}
+
+//Returns the weighted worst-case and total turns from the zones to their nearest rally cell
+func rallyCost(cells []int, weights []float64) (worst, total float64) {
+	for zId, w := range weights {
+		nearest := rules.maxDistance
+		for _, cId := range cells {
+			nearest = minInt(nearest, cellZoneDistances[cId][zId])
+		}
+		cost := w * float64(nearest)
+		worst = math.Max(worst, cost)
+		total += cost
+	}
+	return worst, total
+}
+
+//Returns the owner of each zone
+func zoneOwners() []int {
+	result := make([]int, numZones)
+	for zId, z := range zones {
+		result[zId] = z.owner
+	}
+	return result
+}
+
+//Returns true iff both slices hold the same values
+func sameInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, _ := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+/* RALLY POINTS END */
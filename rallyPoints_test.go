@@ -0,0 +1,68 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"testing"
+)
+
+//Tests that there is a rally point near each cluster of contested zones
+func TestRallyPoints(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	rps := rallyPoints()
+	if len(rps) != 2 {
+		t.Fatal("There should be a rally point per cluster. Got", rps)
+	}
+	for zId, z := range zones {
+		minDist := rules.maxDistance
+		for _, rp := range rps {
+			minDist = minInt(minDist, turnBasedDistance(rp, z.pos))
+		}
+		if minDist > 3 {
+			t.Error("Zone", zId, "is too far from the rally points:", minDist, rps)
+		}
+	}
+	if rp := nearestRallyPoint(0); turnBasedDistance(rp, zones[0].pos) > 3 {
+		t.Error("Drone 0 should rally near its cluster. Got", rp)
+	}
+}
+
+//Tests that a single rally point is never worse than the centroid of the zones
+func TestSolveRallyCellsBeatsCentroid(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	weights := rallyWeights()
+	cells := solveRallyCells(weights, 1)
+	worst, _ := rallyCost(cells, weights)
+	centroidWorst, _ := rallyCost([]int{cellOf(getCentroid(zones))}, weights)
+	if worst > centroidWorst {
+		t.Error("The rally point should not be worse than the centroid:", worst, "Vs", centroidWorst)
+	}
+}
+
+//Tests that the rally points are recalculated when the owners of the zones change
+func TestRallyPointsFollowOwnership(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	before := rallyPoints()
+	for _, zId := range []int{0, 1, 3} {
+		zones[zId].owner = whoami
+	}
+	calculateFrontier()
+	after := rallyPoints()
+	if !sameInts(rallyOwners, zoneOwners()) {
+		t.Error("Rally points should have been recalculated with the new owners", rallyOwners)
+	}
+	if len(after) != 1 || len(before) == len(after) {
+		t.Error("Only the isolated zone should need a rally point. Before", before, "After", after)
+	}
+	if turnBasedDistance(after[0], zones[2].pos) > 1 {
+		t.Error("The rally point should be at the isolated zone. Got", after[0])
+	}
+}
+
+//Tests that the idle drones of the reserve go to their nearest rally point when the bot plays
+func TestIdleDronesGoToRallyPoints(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"frontier\\input.txt", t)
+	decideMoves()
+	if rp := nearestRallyPoint(2); nextMove[2] != rp || squads[squadOf(2)].role != RESERVE {
+		t.Error("Drone 2 is idle and should go to its rally point", rp, "Got", nextMove[2])
+	}
+}
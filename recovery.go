@@ -0,0 +1,67 @@
+//Participating Game of Drones by CodinGame - Recovery from panics during a turn
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strings"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	PANIC_NOTE_PREFIX = "PANIC" //Start of the notes of the turn (and so of the replay lines) that report a panic
+)
+
+var panicCount int //Number of panics recovered in the current game
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* RECOVERY BEGIN */
+
+//Runs the step of the turn. If it panics, the panic is logged with the status of the game, counted and noted in the
+//turn (so it is recorded in the replay), and false is returned so the turn can go on without the step
+func safeRun(name string, step func()) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicCount++
+			fmt.Fprintf(os.Stderr, "\n\n\nRecovered while panicking in %s: %v\nStatus:\n%s\n\n\n", name, r, importableStatus())
+			fmt.Fprint(os.Stderr, string(debug.Stack()))
+			turnInfo(fmt.Sprintf("%s %d in %s: %v", PANIC_NOTE_PREFIX, panicCount, name, r))
+			ok = false
+		}
+	}()
+	step()
+	return true
+}
+
+//Makes sure every drone has a destination: the ones with NO_MOVE stay where they are. If the moves of the turn could not
+//even be initialized, every drone stays
+func completeMoves() {
+	if len(nextMove) != numDronesPerplayer {
+		nextMove = noMoves(numDronesPerplayer)
+	}
+	for dId, d := range players[whoami].drones {
+		if nextMove[dId] == NO_MOVE {
+			nextMove[dId] = d
+		}
+	}
+}
+
+//Returns the number of panics reported in the notes of a recorded game
+func replayPanics(rp replay) (result int) {
+	for _, t := range rp.turns {
+		result += notePanics(t.notes)
+	}
+	return result
+}
+
+//Returns the number of panics reported in the notes of a turn
+func notePanics(notes []string) (result int) {
+	for _, note := range notes {
+		if strings.HasPrefix(note, PANIC_NOTE_PREFIX+" ") {
+			result++
+		}
+	}
+	return result
+}
+
+/* RECOVERY END */
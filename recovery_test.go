@@ -0,0 +1,111 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+//Tests that safeRun recovers, counts and notes panics
+func TestSafeRun(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	if !safeRun("nothing", func() {}) || panicCount != 0 {
+		t.Error("Steps that do not panic should be ok", panicCount)
+	}
+	if safeRun("faulty", func() { panic("boom") }) || panicCount != 1 {
+		t.Error("The panic should be recovered and counted", panicCount)
+	}
+	if last := turnNotes[len(turnNotes)-1]; last != PANIC_NOTE_PREFIX+" 1 in faulty: boom" {
+		t.Error("Wrong note of the panic:", last)
+	}
+}
+
+//Tests that a complete set of moves is printed when a strategy panics, and that the panic is recorded in the replay
+func TestPlayAfterPanic(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	saved := squadBehaviours
+	defer func() { squadBehaviours = saved }()
+	squadBehaviours = append([]squadBehaviour{{"faulty", func() { var a []int; a[1]++ }, []int{RESERVE}}}, saved...)
+	output := captureOutput(play, t)
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != numDronesPerplayer || panicCount != 1 {
+		t.Fatal("Every drone should have a move after the panic:", output, panicCount)
+	}
+	for dId, line := range lines {
+		if line != fmt.Sprint(nextMove[dId].x, nextMove[dId].y) || nextMove[dId] == NO_MOVE {
+			t.Error("Wrong move of drone", dId, line)
+		}
+	}
+	if nextMove[0] != zones[0].pos {
+		t.Error("The rest of the strategies should still run", nextMove)
+	}
+	var buffer bytes.Buffer
+	recordBoard(&buffer)
+	recordTurn(&buffer)
+	rp, err := loadReplay(&buffer)
+	if err != nil || replayPanics(rp) != 1 {
+		t.Error("The panic should be in the replay", err, rp.turns)
+	}
+}
+
+//Tests that the corrected moves are printed when the invariants panic (see strictInvariants)
+func TestPlayAfterInvariantsPanic(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	saved := squadBehaviours
+	defer func() { squadBehaviours = saved }()
+	squadBehaviours = []squadBehaviour{{"outside", func() { assignDestinationPoint(0, point{-5, -5}) }, []int{RESERVE}}}
+	output := captureOutput(play, t)
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != numDronesPerplayer || panicCount != 1 {
+		t.Fatal("Every drone should have a move after the panic:", output, panicCount)
+	}
+	if lines[0] != "0 0" {
+		t.Error("The destination of drone 0 should be inside the board. Got", lines[0])
+	}
+}
+
+//Writer that panics after a number of writes
+type faultyWriter struct {
+	writes, limit int
+}
+
+//Necessary to implement io.Writer
+func (fw *faultyWriter) Write(p []byte) (int, error) {
+	if fw.writes++; fw.writes > fw.limit {
+		panic("disk on fire")
+	}
+	return len(p), nil
+}
+
+//Tests that the game goes on when a step of the turn other than the strategies panics
+func TestGameAfterPanic(t *testing.T) {
+	input := "2 0 2 1\n1000 1000\n" + "-1\n500 500\n600 600\n3500 1500\n3400 1400\n" + "0\n1000 1000\n600 700\n3400 1450\n3400 1400\n"
+	inputReader = strings.NewReader(input)
+	replayWriter = &faultyWriter{limit: 2} //The board can be recorded, but not the turns
+	defer func() { replayWriter = nil }()
+	output := captureOutput(letTheGameBegin, t)
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 2*numDronesPerplayer || panicCount != 2 {
+		t.Error("Both turns should be played:", output, panicCount)
+	}
+}
+
+//Tests that drones without destination stay where they are
+func TestCompleteMoves(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"minimax\\input.txt", t)
+	nextMove = nil
+	completeMoves()
+	for dId, d := range players[whoami].drones {
+		if nextMove[dId] != d {
+			t.Error("Drone", dId, "should stay. Got", nextMove[dId])
+		}
+	}
+	nextMove[1] = NO_MOVE
+	nextMove[0] = zones[0].pos
+	completeMoves()
+	if nextMove[0] != zones[0].pos || nextMove[1] != players[whoami].drones[1] {
+		t.Error("Only drone 1 should be completed", nextMove)
+	}
+}
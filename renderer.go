@@ -0,0 +1,235 @@
+//Participating Game of Drones by CodinGame - Terminal renderer of the board
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	RENDER_TURNS    = false //True iff the board must be drawn in the turn information
+	DEFAULT_COLUMNS = 80    //Width of the terminal when it cannot be guessed
+	DEFAULT_LINES   = 24    //Height of the terminal when it cannot be guessed
+	NO_COLOUR       = -1    //Colour of the characters that are not coloured
+)
+
+var playerColours = [MAX_PLAYERS]int{31, 32, 34, 33, 35, 36, 37, 90}          //ANSI colour of each player's zones and drones. Array index = player's ID
+var playerSymbols = [MAX_PLAYERS]byte{'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h'} //Character of each player's drones. Array index = player's ID
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* DATA TYPES BEGIN */
+
+//Character of the rendered board
+type glyph struct {
+	char   byte //What is drawn
+	colour int  //ANSI colour of the character or NO_COLOUR
+}
+
+//Board drawn as a grid of characters
+type canvas struct {
+	width, height int       //Number of columns and rows
+	glyphs        [][]glyph //Characters. First index = row
+	dronePlayers  [][]int   //Player whose drones are drawn in each character, -1 if none. First index = row
+}
+
+/* DATA TYPES END ******************************************************************** RENDERING BEGIN */
+
+//Returns the current status of the board drawn in width x height characters plus a legend.
+//Zones are drawn with dots coloured by owner and their ID in the centre, drones with the letter of their player
+//(uppercase for my drones, '#' if several players share a character) and my destinations with arrows
+func renderBoard(width, height int, colour bool) string {
+	c := newCanvas(width, height)
+	for dId, d := range players[whoami].drones {
+		if dId < len(nextMove) && nextMove[dId] != NO_MOVE {
+			c.drawArrow(d, nextMove[dId], playerColour(whoami))
+		}
+	}
+	for zId, z := range zones {
+		c.drawZone(zId, z)
+	}
+	for pId, p := range players {
+		for _, d := range p.drones {
+			c.drawDrone(pId, d)
+		}
+	}
+	return c.text(colour) + renderLegend(colour)
+}
+
+//Returns the size of the terminal as told by the environment, or the default one
+func terminalSize() (width, height int) {
+	width, height = DEFAULT_COLUMNS, DEFAULT_LINES
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		width = columns
+	}
+	if lines, err := strconv.Atoi(os.Getenv("LINES")); err == nil && lines > numPlayers+2 {
+		height = lines - numPlayers - 2
+	}
+	return width, height
+}
+
+//Returns one line per player with its symbol, score, number of zones and spare drones, a line with the frontier
+//status of each zone (if the frontier of the turn has been analyzed) and a line with the turns my first drone and the
+//first drone of my fastest enemy need to reach each zone
+func renderLegend(colour bool) string {
+	var result bytes.Buffer
+	for pId, p := range players {
+		numZonesPlayer := 0
+		for _, z := range zones {
+			if z.owner == pId {
+				numZonesPlayer++
+			}
+		}
+		symbol := glyph{playerSymbol(pId), playerColour(pId)}
+		if pId == whoami {
+			symbol.char -= 'a' - 'A'
+		}
+		result.WriteString(fmt.Sprintf("%s player %d - score: %d zones: %d", symbol.render(colour), pId, p.score, numZonesPlayer))
+		if len(spareDrones) == numPlayers {
+			result.WriteString(fmt.Sprintf(" spare drones: %d", spareDrones[pId]))
+		}
+		result.WriteString("\n")
+	}
+	if len(frontier) == numZones {
+		result.WriteString("frontier:")
+		for zId, status := range frontier {
+			result.WriteString(fmt.Sprintf(" %d=%s", zId, frontierName(status)))
+		}
+		result.WriteString("\n")
+	}
+	if len(earliestArrivals) == numPlayers {
+		result.WriteString("race:")
+		for zId, _ := range zones {
+			result.WriteString(fmt.Sprintf(" %d=%d/%d", zId, earliestArrival(whoami, zId, 1), enemyEarliestArrival(zId, 1)))
+		}
+		result.WriteString("\n")
+	}
+	return result.String()
+}
+
+//Returns an empty canvas
+func newCanvas(width, height int) canvas {
+	c := canvas{width, height, make([][]glyph, height), make([][]int, height)}
+	for row, _ := range c.glyphs {
+		c.glyphs[row] = make([]glyph, width)
+		c.dronePlayers[row] = make([]int, width)
+		for col, _ := range c.glyphs[row] {
+			c.glyphs[row][col] = glyph{' ', NO_COLOUR}
+			c.dronePlayers[row][col] = -1
+		}
+	}
+	return c
+}
+
+//Returns the column and row where a point of the board is drawn
+func (c canvas) project(p point) (col, row int) {
+	col = maxInt(0, minInt(c.width-1, p.x*c.width/rules.Width))
+	row = maxInt(0, minInt(c.height-1, p.y*c.height/rules.Height))
+	return col, row
+}
+
+//Draws a character at the given column and row
+func (c canvas) set(col, row int, g glyph) {
+	if row >= 0 && row < c.height && col >= 0 && col < c.width {
+		c.glyphs[row][col] = g
+	}
+}
+
+//Draws the area of the zone with dots and its ID in the centre
+func (c canvas) drawZone(zId int, z zone) {
+	for row := 0; row < c.height; row += 1 {
+		for col := 0; col < c.width; col += 1 {
+			centre := point{(2*col + 1) * rules.Width / (2 * c.width), (2*row + 1) * rules.Height / (2 * c.height)}
+			if euclideanDistance(centre, z.pos) <= rules.ZoneRadius {
+				c.set(col, row, glyph{'.', playerColour(z.owner)})
+			}
+		}
+	}
+	col, row := c.project(z.pos)
+	c.set(col, row, glyph{strconv.FormatInt(int64(zId%36), 36)[0], playerColour(z.owner)})
+}
+
+//Draws a drone of the given player. Several players in the same character are drawn as '#'
+func (c canvas) drawDrone(pId int, d point) {
+	col, row := c.project(d)
+	symbol := playerSymbol(pId)
+	if pId == whoami {
+		symbol -= 'a' - 'A'
+	}
+	if c.dronePlayers[row][col] >= 0 && c.dronePlayers[row][col] != pId {
+		c.set(col, row, glyph{'#', NO_COLOUR})
+		return
+	}
+	c.dronePlayers[row][col] = pId
+	c.set(col, row, glyph{symbol, playerColour(pId)})
+}
+
+//Draws a line from a point to another with an arrowhead at the end
+func (c canvas) drawArrow(from, to point, colour int) {
+	col0, row0 := c.project(from)
+	col1, row1 := c.project(to)
+	dCol, dRow := col1-col0, row1-row0
+	steps := maxInt(absInt(dCol), absInt(dRow))
+	if steps == 0 {
+		return
+	}
+	line := byte('-')
+	if absInt(dRow) > 2*absInt(dCol) {
+		line = '|'
+	} else if 2*absInt(dRow) > absInt(dCol) {
+		if (dCol > 0) == (dRow > 0) {
+			line = '\\'
+		} else {
+			line = '/'
+		}
+	}
+	for i := 1; i < steps; i += 1 {
+		c.set(col0+(dCol*i+steps/2)/steps, row0+(dRow*i+steps/2)/steps, glyph{line, colour})
+	}
+	head := byte('>')
+	switch {
+	case absInt(dRow) > absInt(dCol) && dRow > 0:
+		head = 'v'
+	case absInt(dRow) > absInt(dCol):
+		head = '^'
+	case dCol < 0:
+		head = '<'
+	}
+	c.set(col1, row1, glyph{head, colour})
+}
+
+//Returns the canvas as text, one line per row
+func (c canvas) text(colour bool) string {
+	var result bytes.Buffer
+	for _, row := range c.glyphs {
+		for _, g := range row {
+			result.WriteString(g.render(colour))
+		}
+		result.WriteByte('\n')
+	}
+	return result.String()
+}
+
+//Returns the character, surrounded by its ANSI colour codes if colour is enabled
+func (g glyph) render(colour bool) string {
+	if !colour || g.colour == NO_COLOUR {
+		return string(g.char)
+	}
+	return fmt.Sprintf("\x1b[%dm%c\x1b[0m", g.colour, g.char)
+}
+
+//Returns the colour of the given player (NO_COLOUR for unreclaimed zones)
+func playerColour(pId int) int {
+	if pId < 0 {
+		return NO_COLOUR
+	}
+	return playerColours[pId%len(playerColours)]
+}
+
+//Returns the lowercase letter of the drones of the given player
+func playerSymbol(pId int) byte {
+	return playerSymbols[pId%len(playerSymbols)]
+}
+
+/* RENDERING END */
@@ -0,0 +1,76 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+//Tests method renderBoard without colours. The board is 40x18 characters so each character is 100x100 units
+func TestRenderBoard(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	assignDestinationPoint(1, point{3000, 600})
+	lines := strings.Split(renderBoard(40, 18, false), "\n")
+	if len(lines) != 18+numPlayers+3 {
+		t.Fatal("Wrong number of lines:", len(lines))
+	}
+	var testCases = []struct {
+		col, row int
+		out      byte
+	}{
+		{5, 5, 'A'},   //my drone over zone 0
+		{9, 5, '1'},   //centre of zone 1
+		{8, 5, '.'},   //area of zone 1
+		{34, 14, 'b'}, //enemy drone
+		{20, 6, '-'},  //path of drone 1
+		{30, 6, '>'},  //destination of drone 1
+		{0, 0, ' '},   //nothing
+	}
+	for i, testCase := range testCases {
+		if result := lines[testCase.row][testCase.col]; result != testCase.out {
+			t.Error("Error in item", i, "Got", string(result), "Expected", string(testCase.out))
+		}
+	}
+	if !strings.HasPrefix(lines[18], "A player 0") || !strings.HasPrefix(lines[19], "b player 1") {
+		t.Error("Wrong legend:", lines[18:])
+	}
+	if !strings.HasPrefix(lines[18+numPlayers], "frontier: 0=") || !strings.Contains(lines[18], "spare drones:") {
+		t.Error("Wrong frontier in the legend:", lines[18:])
+	}
+	if !strings.HasPrefix(lines[19+numPlayers], "race: 0=") {
+		t.Error("Wrong arrival race in the legend:", lines[18:])
+	}
+}
+
+//Tests that colours are only used when enabled
+func TestRenderBoardColours(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	if strings.Contains(renderBoard(40, 18, false), "\x1b[") {
+		t.Error("There should not be colours in the plain board")
+	}
+	zones[1].owner = 1
+	if !strings.Contains(renderBoard(40, 18, true), "\x1b[32m1\x1b[0m") {
+		t.Error("Zone 1 should be coloured as player 1")
+	}
+}
+
+//Tests that drones of different players in the same character are drawn together
+func TestRenderBoardSharedCharacter(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	players[1].drones[0] = point{510, 510}
+	lines := strings.Split(renderBoard(40, 18, false), "\n")
+	if lines[5][5] != '#' {
+		t.Error("Drones of two players should be drawn as '#'. Got", string(lines[5][5]))
+	}
+}
+
+//Tests that every player is drawn with its own symbol and colour
+func TestPlayerGlyphs(t *testing.T) {
+	for pId := 0; pId < MAX_PLAYERS; pId += 1 {
+		for other := 0; other < pId; other += 1 {
+			if playerSymbol(pId) == playerSymbol(other) || playerColour(pId) == playerColour(other) {
+				t.Error("Players", other, "and", pId, "are drawn alike")
+			}
+		}
+	}
+}
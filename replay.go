@@ -0,0 +1,226 @@
+//Participating Game of Drones by CodinGame - Recording and loading of whole games
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	RECORDING   = false        //True iff the game must be recorded
+	REPLAY_PATH = "replay.txt" //Path to the file that stores the recorded game
+)
+
+var ( //recording-related variables
+	replayWriter io.Writer //Where the game is recorded. nil if it is not being recorded
+	turnNotes    []string  //Information written with turnInfo during the turn
+)
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* DATA TYPES BEGIN */
+
+//A recorded game. Its text format is the input of the game (see readBoard and parseTurn) where each turn is followed by
+//the destinations of my drones (one line "x y" per drone), the number of notes of the turn and the notes (one per line).
+//The first line also has the rules of the game (see parseRules). The games recorded without them follow the official ones
+type replay struct {
+	rules      gameRules    //Rules of the game
+	numPlayers int          //Number of players in the game
+	whoami     int          //Index of the recorded player
+	numDrones  int          //Number of drones each player has
+	zones      []point      //Centre of each zone
+	turns      []replayTurn //Information of each turn. Array index = turn - 1
+}
+
+//Information of a recorded turn
+type replayTurn struct {
+	owners []int     //Owner of each zone
+	drones [][]point //Position of each drone of each player
+	moves  []point   //Destination of each of my drones
+	notes  []string  //Reasons written with turnInfo
+}
+
+/* DATA TYPES END ******************************************************************** RECORDING BEGIN */
+
+//Starts recording the game into REPLAY_PATH
+func startRecording() {
+	f, err := os.Create(REPLAY_PATH)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error creating replay file:", err)
+		return
+	}
+	replayWriter = f
+}
+
+//Writes the board and the rules into the recorded game
+func recordBoard(w io.Writer) {
+	fmt.Fprintf(w, "%d %d %d %d %v\n", numPlayers, whoami, numDronesPerplayer, numZones, rules)
+	for _, z := range zones {
+		fmt.Fprintf(w, "%d %d\n", z.pos.x, z.pos.y)
+	}
+}
+
+//Writes the current turn, my moves and the notes of the turn into the recorded game
+func recordTurn(w io.Writer) {
+	for _, z := range zones {
+		fmt.Fprintf(w, "%d\n", z.owner)
+	}
+	for _, p := range players {
+		for _, d := range p.drones {
+			fmt.Fprintf(w, "%d %d\n", d.x, d.y)
+		}
+	}
+	for _, m := range nextMove {
+		fmt.Fprintf(w, "%d %d\n", m.x, m.y)
+	}
+	notes := make([]string, 0, len(turnNotes))
+	for _, note := range turnNotes {
+		notes = append(notes, strings.Split(note, "\n")...)
+	}
+	fmt.Fprintf(w, "%d\n", len(notes))
+	for _, note := range notes {
+		fmt.Fprintln(w, note)
+	}
+}
+
+/* RECORDING END ******************************************************************** LOADING BEGIN */
+
+//Reads a recorded game. The owners of the zones and the positions of the zones and the drones are checked against the
+//rules of the game so it can be restored (see restoreTurn)
+func loadReplay(r io.Reader) (result replay, err error) {
+	in := bufio.NewReader(r)
+	header, err := in.ReadString('\n')
+	if err != nil && (err != io.EOF || header == "") {
+		return result, fmt.Errorf("reading header: %v", err)
+	}
+	fields := strings.Fields(header)
+	if len(fields) != 4 && len(fields) != 5 {
+		return result, fmt.Errorf("wrong header: %q", header)
+	}
+	var numZonesReplay int
+	if _, err = fmt.Sscanf(strings.Join(fields[:4], " "), "%d %d %d %d", &result.numPlayers, &result.whoami, &result.numDrones, &numZonesReplay); err != nil {
+		return result, fmt.Errorf("reading header: %v", err)
+	}
+	result.rules = officialRules
+	if len(fields) == 5 {
+		if result.rules, err = parseRules(fields[4]); err != nil {
+			return result, fmt.Errorf("reading header: %v", err)
+		}
+	}
+	if result.numPlayers <= 0 || result.numPlayers > MAX_PLAYERS || result.whoami < 0 || result.whoami >= result.numPlayers ||
+		result.numDrones <= 0 || result.numDrones > MAX_DRONES_PER_PLAYER || numZonesReplay <= 0 || numZonesReplay > MAX_ZONES {
+		return result, fmt.Errorf("wrong header: %d %d %d %d", result.numPlayers, result.whoami, result.numDrones, numZonesReplay)
+	}
+	result.zones = make([]point, numZonesReplay)
+	for zId, _ := range result.zones {
+		if _, err = fmt.Fscanf(in, "%d %d\n", &result.zones[zId].x, &result.zones[zId].y); err != nil {
+			return result, fmt.Errorf("reading zone %d: %v", zId, err)
+		}
+		if z := result.zones[zId]; !result.rules.inside([2]int{z.x, z.y}) {
+			return result, fmt.Errorf("zone %d out of the board in %v", zId, z)
+		}
+	}
+	for {
+		t, more, err := loadReplayTurn(in, result)
+		if err != nil {
+			return result, fmt.Errorf("reading turn %d: %v", len(result.turns)+1, err)
+		}
+		if !more {
+			return result, nil
+		}
+		result.turns = append(result.turns, t)
+	}
+}
+
+//Reads a turn of a recorded game. more is false if the game has finished
+func loadReplayTurn(in *bufio.Reader, rp replay) (result replayTurn, more bool, err error) {
+	result.owners = make([]int, len(rp.zones))
+	for zId, _ := range result.owners {
+		if _, err = fmt.Fscanf(in, "%d\n", &result.owners[zId]); err != nil {
+			if zId == 0 && err == io.EOF {
+				return result, false, nil
+			}
+			return result, false, err
+		}
+		if result.owners[zId] < UNRECLAIMED || result.owners[zId] >= rp.numPlayers {
+			return result, false, fmt.Errorf("wrong owner of zone %d: %d", zId, result.owners[zId])
+		}
+	}
+	result.drones = make([][]point, rp.numPlayers)
+	for pId, _ := range result.drones {
+		result.drones[pId] = make([]point, rp.numDrones)
+		for dId, _ := range result.drones[pId] {
+			if _, err = fmt.Fscanf(in, "%d %d\n", &result.drones[pId][dId].x, &result.drones[pId][dId].y); err != nil {
+				return result, false, err
+			}
+			if d := result.drones[pId][dId]; !rp.rules.inside([2]int{d.x, d.y}) {
+				return result, false, fmt.Errorf("drone %d of player %d out of the board in %v", dId, pId, d)
+			}
+		}
+	}
+	result.moves = make([]point, rp.numDrones)
+	for dId, _ := range result.moves {
+		if _, err = fmt.Fscanf(in, "%d %d\n", &result.moves[dId].x, &result.moves[dId].y); err != nil {
+			return result, false, err
+		}
+	}
+	var numNotes int
+	if _, err = fmt.Fscanf(in, "%d\n", &numNotes); err != nil {
+		return result, false, err
+	}
+	if numNotes < 0 {
+		return result, false, fmt.Errorf("wrong number of notes: %d", numNotes)
+	}
+	result.notes = make([]string, numNotes)
+	for i, _ := range result.notes {
+		note, err := in.ReadString('\n')
+		if err != nil && (err != io.EOF || note == "") {
+			return result, false, err
+		}
+		result.notes[i] = strings.TrimRight(note, "\r\n")
+	}
+	return result, true, nil
+}
+
+//Reads a recorded game from a file
+func loadReplayFile(path string) (replay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return replay{}, err
+	}
+	defer f.Close()
+	return loadReplay(f)
+}
+
+//Sets the status of the game (rules, board, owners, drones, scores, my moves and notes) to the given turn (the first
+//one is 1)
+func restoreTurn(rp replay, t int) error {
+	if t < 1 || t > len(rp.turns) {
+		return fmt.Errorf("turn %d out of range [1, %d]", t, len(rp.turns))
+	}
+	rules = rp.rules
+	numPlayers, whoami, numDronesPerplayer, numZones = rp.numPlayers, rp.whoami, rp.numDrones, len(rp.zones)
+	turn = t
+	zones = make([]zone, numZones)
+	for zId, _ := range zones {
+		zones[zId] = zone{rp.zones[zId], rp.turns[t-1].owners[zId]}
+	}
+	players = make([]player, numPlayers)
+	for pId, _ := range players {
+		players[pId].drones = append([]point(nil), rp.turns[t-1].drones[pId]...)
+	}
+	for _, rt := range rp.turns[:t] {
+		for _, owner := range rt.owners {
+			if owner >= 0 && owner < numPlayers {
+				players[owner].score++
+			}
+		}
+	}
+	nextMove = append([]point(nil), rp.turns[t-1].moves...)
+	turnNotes = append([]string(nil), rp.turns[t-1].notes...)
+	return nil
+}
+
+/* LOADING END */
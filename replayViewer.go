@@ -0,0 +1,252 @@
+//Participating Game of Drones by CodinGame - HTML viewer of recorded games
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	VIEWER_TRAIL_LENGTH = 8   //Number of previous positions drawn behind each drone
+	VIEWER_TURN_MILLIS  = 250 //Milliseconds each turn is shown while playing
+)
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* DATA TYPES BEGIN */
+
+//Recorded game as it is embedded in the viewer
+type viewerGame struct {
+	Width       int          `json:"width"`       //Width of the board
+	Height      int          `json:"height"`      //Height of the board
+	ZoneRadius  float64      `json:"zoneRadius"`  //Radius of the zones
+	Whoami      int          `json:"whoami"`      //Index of the recorded player
+	Colours     []string     `json:"colours"`     //Colour of each player
+	Unreclaimed string       `json:"unreclaimed"` //Colour of the zones nobody owns
+	TrailLength int          `json:"trailLength"` //Number of previous positions drawn behind each drone
+	TurnMillis  int          `json:"turnMillis"`  //Milliseconds each turn is shown while playing
+	PanicPrefix string       `json:"panicPrefix"` //Start of the notes that report a panic
+	Zones       [][2]int     `json:"zones"`       //Centre of each zone
+	Turns       []viewerTurn `json:"turns"`       //Information of each turn
+}
+
+//Recorded turn as it is embedded in the viewer
+type viewerTurn struct {
+	Owners []int      `json:"owners"` //Owner of each zone
+	Scores []int      `json:"scores"` //Score of each player at the end of the turn
+	Drones [][][2]int `json:"drones"` //Position of each drone of each player
+	Moves  [][2]int   `json:"moves"`  //Destination of each of my drones
+	Notes  []string   `json:"notes"`  //Reasons of my moves
+	Panics int        `json:"panics"` //Number of panics recovered in the turn (see safeRun)
+}
+
+/* DATA TYPES END ******************************************************************** REPLAY VIEWER BEGIN */
+
+//Returns a self-contained HTML page that plays the recorded game
+func replayViewer(rp replay) (string, error) {
+	game := viewerGame{rp.rules.Width, rp.rules.Height, rp.rules.ZoneRadius, rp.whoami, svgPlayerColours[:], SVG_UNRECLAIMED_COLOUR,
+		VIEWER_TRAIL_LENGTH, VIEWER_TURN_MILLIS, PANIC_NOTE_PREFIX, make([][2]int, len(rp.zones)), make([]viewerTurn, len(rp.turns))}
+	for zId, z := range rp.zones {
+		game.Zones[zId] = [2]int{z.x, z.y}
+	}
+	scores := make([]int, rp.numPlayers)
+	for t, rt := range rp.turns {
+		for _, owner := range rt.owners {
+			if owner >= 0 && owner < rp.numPlayers {
+				scores[owner]++
+			}
+		}
+		vt := viewerTurn{rt.owners, append([]int(nil), scores...), make([][][2]int, rp.numPlayers), toPairs(rt.moves), rt.notes,
+			notePanics(rt.notes)}
+		for pId, drones := range rt.drones {
+			vt.Drones[pId] = toPairs(drones)
+		}
+		game.Turns[t] = vt
+	}
+	data, err := json.Marshal(game)
+	if err != nil {
+		return "", err
+	}
+	return strings.Replace(VIEWER_TEMPLATE, "/*GAME*/null", string(data), 1), nil
+}
+
+//Returns the points as pairs of coordinates
+func toPairs(ps []point) [][2]int {
+	result := make([][2]int, len(ps))
+	for i, p := range ps {
+		result[i] = [2]int{p.x, p.y}
+	}
+	return result
+}
+
+//Writes the viewer of a recorded game into the standard output or the given file
+func commandHTML(args []string) int {
+	if len(args) < 1 || len(args) > 2 {
+		return EXIT_USAGE
+	}
+	rp, err := loadReplayFile(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error loading replay:", err)
+		return EXIT_ERROR
+	}
+	page, err := replayViewer(rp)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error generating viewer:", err)
+		return EXIT_ERROR
+	}
+	if len(args) == 1 {
+		fmt.Print(page)
+		return EXIT_OK
+	}
+	if err := os.WriteFile(args[1], []byte(page), 0644); err != nil {
+		fmt.Fprintln(os.Stderr, "Error writing viewer:", err)
+		return EXIT_ERROR
+	}
+	return EXIT_OK
+}
+
+//Page of the viewer. /*GAME*/null is replaced by the recorded game
+const VIEWER_TEMPLATE = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Game of Drones - replay</title>
+<style>
+body { font-family: monospace; margin: 10px; display: flex; }
+#main { flex: 3; }
+#side { flex: 1; margin-left: 10px; max-height: 95vh; overflow-y: auto; }
+canvas { width: 100%; border: 1px solid #000; }
+#controls { display: flex; align-items: center; margin-top: 5px; }
+#timeline { flex: 1; margin: 0 10px; }
+.note { white-space: pre-wrap; border-bottom: 1px solid #ddd; }
+.panic { color: #c00; font-weight: bold; }
+</style>
+</head>
+<body>
+<div id="main">
+<canvas id="board"></canvas>
+<div id="controls">
+<button id="play">Play</button>
+<input id="timeline" type="range" min="1" value="1">
+<span id="turn"></span>
+<span id="panics" class="panic"></span>
+</div>
+<div id="scores"></div>
+</div>
+<div id="side"><h3>Reasons</h3><div id="notes"></div></div>
+<script>
+var game = /*GAME*/null;
+var board = document.getElementById("board");
+var context = board.getContext("2d");
+var timeline = document.getElementById("timeline");
+var playButton = document.getElementById("play");
+var timer = null;
+board.width = 1200;
+board.height = Math.round(1200 * game.height / game.width);
+var scale = board.width / game.width;
+timeline.max = game.turns.length;
+
+function colour(pId) {
+	return pId < 0 ? game.unreclaimed : game.colours[pId % game.colours.length];
+}
+
+function circle(x, y, r, fill, stroke) {
+	context.beginPath();
+	context.arc(x * scale, y * scale, r, 0, 2 * Math.PI);
+	if (fill) { context.fillStyle = fill; context.fill(); }
+	if (stroke) { context.strokeStyle = stroke; context.stroke(); }
+}
+
+function draw(t) {
+	var turn = game.turns[t - 1];
+	context.clearRect(0, 0, board.width, board.height);
+	game.zones.forEach(function (z, zId) {
+		context.globalAlpha = 0.3;
+		circle(z[0], z[1], game.zoneRadius * scale, colour(turn.owners[zId]), null);
+		context.globalAlpha = 1;
+		circle(z[0], z[1], game.zoneRadius * scale, null, colour(turn.owners[zId]));
+		context.fillStyle = "#000";
+		context.fillText(zId, z[0] * scale - 3, (z[1] + game.zoneRadius) * scale + 12);
+	});
+	turn.drones.forEach(function (drones, pId) {
+		drones.forEach(function (d, dId) {
+			context.strokeStyle = colour(pId);
+			context.globalAlpha = 0.4;
+			context.beginPath();
+			context.moveTo(d[0] * scale, d[1] * scale);
+			for (var i = t - 2; i >= 0 && i >= t - 1 - game.trailLength; i--) {
+				var previous = game.turns[i].drones[pId][dId];
+				context.lineTo(previous[0] * scale, previous[1] * scale);
+			}
+			context.stroke();
+			context.globalAlpha = 1;
+		});
+	});
+	turn.moves.forEach(function (m, dId) {
+		var d = turn.drones[game.whoami][dId];
+		context.setLineDash([6, 4]);
+		context.strokeStyle = colour(game.whoami);
+		context.beginPath();
+		context.moveTo(d[0] * scale, d[1] * scale);
+		context.lineTo(m[0] * scale, m[1] * scale);
+		context.stroke();
+		context.setLineDash([]);
+	});
+	turn.drones.forEach(function (drones, pId) {
+		drones.forEach(function (d) {
+			circle(d[0], d[1], 5, colour(pId), pId == game.whoami ? "#000" : null);
+		});
+	});
+	document.getElementById("turn").textContent = "Turn " + t + " / " + game.turns.length;
+	document.getElementById("panics").textContent = turn.panics > 0 ? " " + turn.panics + " panic(s)" : "";
+	var scores = document.getElementById("scores");
+	scores.innerHTML = "";
+	turn.scores.forEach(function (score, pId) {
+		var line = document.createElement("div");
+		line.style.color = colour(pId);
+		line.textContent = "player " + pId + (pId == game.whoami ? " (ME)" : "") + " score: " + score;
+		scores.appendChild(line);
+	});
+	var notes = document.getElementById("notes");
+	notes.innerHTML = "";
+	turn.notes.forEach(function (note) {
+		var line = document.createElement("div");
+		line.className = note.indexOf(game.panicPrefix + " ") == 0 ? "note panic" : "note";
+		line.textContent = note;
+		notes.appendChild(line);
+	});
+}
+
+function stop() {
+	clearInterval(timer);
+	timer = null;
+	playButton.textContent = "Play";
+}
+
+playButton.onclick = function () {
+	if (timer) {
+		stop();
+		return;
+	}
+	if (+timeline.value >= game.turns.length) {
+		timeline.value = 1;
+	}
+	playButton.textContent = "Pause";
+	timer = setInterval(function () {
+		if (+timeline.value >= game.turns.length) {
+			stop();
+			return;
+		}
+		timeline.value = +timeline.value + 1;
+		draw(+timeline.value);
+	}, game.turnMillis);
+};
+timeline.oninput = function () { draw(+timeline.value); };
+if (game.turns.length > 0) { draw(1); }
+</script>
+</body>
+</html>
+`
+
+/* REPLAY VIEWER END */
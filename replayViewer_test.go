@@ -0,0 +1,73 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//Tests method replayViewer
+func TestReplayViewer(t *testing.T) {
+	page, err := replayViewer(loadTestReplay(t))
+	if err != nil {
+		t.Fatal("Error generating viewer:", err)
+	}
+	if strings.Contains(page, "/*GAME*/") {
+		t.Error("The game should be embedded in the page")
+	}
+	if strings.Contains(page, "src=") || strings.Contains(page, "href=") {
+		t.Error("The page should not use external assets")
+	}
+	begin := strings.Index(page, "var game = ") + len("var game = ")
+	end := strings.Index(page[begin:], ";\n") + begin
+	var game viewerGame
+	if err := json.Unmarshal([]byte(page[begin:end]), &game); err != nil {
+		t.Fatal("Wrong embedded game:", err)
+	}
+	if len(game.Turns) != 2 || len(game.Zones) != 4 || game.Whoami != 0 || game.Width != officialRules.Width {
+		t.Error("Wrong embedded game:", game)
+	}
+	if game.Turns[0].Scores[0] != 0 || game.Turns[1].Scores[0] != 1 {
+		t.Error("Wrong scores:", game.Turns[0].Scores, game.Turns[1].Scores)
+	}
+	if game.Turns[1].Notes[0] != "Moving drone 0 to zone 0 because Too risky to move" {
+		t.Error("Wrong notes:", game.Turns[1].Notes)
+	}
+}
+
+//Tests that the panics of the recorded game are shown in the viewer
+func TestReplayViewerPanics(t *testing.T) {
+	rp := loadTestReplay(t)
+	rp.turns[1].notes = append(rp.turns[1].notes, PANIC_NOTE_PREFIX+" 1 in faulty: boom")
+	page, err := replayViewer(rp)
+	if err != nil {
+		t.Fatal("Error generating viewer:", err)
+	}
+	begin := strings.Index(page, "var game = ") + len("var game = ")
+	end := strings.Index(page[begin:], ";\n") + begin
+	var game viewerGame
+	if err := json.Unmarshal([]byte(page[begin:end]), &game); err != nil {
+		t.Fatal("Wrong embedded game:", err)
+	}
+	if game.Turns[0].Panics != 0 || game.Turns[1].Panics != 1 || game.PanicPrefix != PANIC_NOTE_PREFIX {
+		t.Error("Wrong panics:", game.Turns[0].Panics, game.Turns[1].Panics, game.PanicPrefix)
+	}
+}
+
+//Tests the html command
+func TestCommandHTML(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "game.html")
+	replayPath := strings.Replace(REPLAY_TEST_FILE, "\\", "/", -1)
+	if code := commandHTML([]string{replayPath, output}); code != EXIT_OK {
+		t.Fatal("Wrong exit code:", code)
+	}
+	if content, err := os.ReadFile(output); err != nil || !strings.HasPrefix(string(content), "<!DOCTYPE html>") {
+		t.Error("Wrong page:", err)
+	}
+	if code := commandHTML([]string{}); code != EXIT_USAGE {
+		t.Error("Replay is mandatory. Exit code:", code)
+	}
+}
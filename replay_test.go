@@ -0,0 +1,138 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const REPLAY_TEST_FILE = FILE_TESTS_BASE + "replay\\game.txt"
+
+//Opens the recorded game used for testing
+func loadTestReplay(t *testing.T) replay {
+	rp, err := loadReplayFile(strings.Replace(REPLAY_TEST_FILE, "\\", "/", -1))
+	if err != nil {
+		t.Fatal("Error loading replay:", err)
+	}
+	return rp
+}
+
+//Tests method loadReplay
+func TestLoadReplay(t *testing.T) {
+	rp := loadTestReplay(t)
+	if rp.numPlayers != 2 || rp.whoami != 0 || rp.numDrones != 2 || len(rp.zones) != 4 {
+		t.Error("Wrong header:", rp)
+	}
+	if len(rp.turns) != 2 {
+		t.Fatal("Wrong number of turns:", len(rp.turns))
+	}
+	if rp.turns[1].owners[0] != 0 || rp.turns[1].drones[1][0] != (point{3400, 1450}) || rp.turns[1].moves[1] != (point{700, 800}) {
+		t.Error("Wrong second turn:", rp.turns[1])
+	}
+	if len(rp.turns[1].notes) != 2 || rp.turns[1].notes[0] != "Moving drone 0 to zone 0 because Too risky to move" {
+		t.Error("Wrong notes:", rp.turns[1].notes)
+	}
+}
+
+//Tests that a recorded turn can be loaded back
+func TestRecordTurn(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	var buffer bytes.Buffer
+	recordBoard(&buffer)
+	assignDestinationPointNoisy(1, point{1000, 1000}, "Two lines\nof reasons")
+	recordTurn(&buffer)
+	rp, err := loadReplay(&buffer)
+	if err != nil {
+		t.Fatal("Error loading replay:", err)
+	}
+	if len(rp.turns) != 1 || rp.turns[0].moves[1] != (point{1000, 1000}) || rp.turns[0].drones[1][1] != players[1].drones[1] {
+		t.Error("Wrong turn:", rp.turns)
+	}
+	if len(rp.turns[0].notes) != 2 || rp.turns[0].notes[1] != "of reasons" {
+		t.Error("Multiline notes should be split:", rp.turns[0].notes)
+	}
+}
+
+//Tests that the rules of the game are recorded and restored with the game
+func TestRecordRules(t *testing.T) {
+	defer func() { rules = officialRules }()
+	if rp := loadTestReplay(t); rp.rules != officialRules {
+		t.Error("Games recorded without rules should follow the official ones. Got", rp.rules)
+	}
+	setBoard(botBoard{2, 0, 1, [][2]int{{5000, 2000}}, newGameRules(8000, 3000, 200, 150, 100)})
+	setTurn(botTurn{[]int{-1}, [][][2]int{{{100, 100}}, {{7000, 2900}}}})
+	var buffer bytes.Buffer
+	recordBoard(&buffer)
+	nextMove = []point{{100, 100}}
+	recordTurn(&buffer)
+	custom := rules
+	rules = officialRules
+	rp, err := loadReplay(&buffer)
+	if err != nil {
+		t.Fatal("Error loading replay:", err)
+	}
+	if rp.rules != custom {
+		t.Error("Wrong rules:", rp.rules, "Expected", custom)
+	}
+	if rules != officialRules {
+		t.Error("Loading a game should not change the rules. Got", rules)
+	}
+	if err := restoreTurn(rp, 1); err != nil || rules != custom {
+		t.Error("The rules should be restored with the turn:", err, rules)
+	}
+}
+
+//Tests that incomplete games cannot be loaded
+func TestLoadReplayTruncated(t *testing.T) {
+	var testCases = []string{
+		"",
+		"2 0 2 4\n500 500\n",
+		"2 0 2 1\n500 500\n-1\n1 1\n2 2\n",
+		"2 5 2 1\n500 500\n",
+		"2 0 2 1 4000,1800\n500 500\n",
+	}
+	for i, testCase := range testCases {
+		if _, err := loadReplay(strings.NewReader(testCase)); err == nil {
+			t.Error("Error in item", i, "Replay should not be loaded")
+		}
+	}
+}
+
+//Tests that games that cannot be restored are not loaded
+func TestLoadReplayMalformed(t *testing.T) {
+	turn := "500 500\n600 600\n3500 1500\n3400 1400\n500 500\n700 800\n0\n"
+	var testCases = []string{
+		"2 0 2 1\n500 500\n7\n" + turn,                                         //wrong owner
+		"2 0 2 1\n500 500\n-2\n" + turn,                                        //wrong owner
+		"2 0 2 1\n5000 500\n-1\n" + turn,                                       //zone out of the board
+		"2 0 2 1\n500 500\n-1\n500 500\n600 -600\n" + turn[16:],                //drone out of the board
+		"2 0 2 1\n500 500\n-1\n" + strings.Replace(turn, "\n0\n", "\n-1\n", 1), //wrong number of notes
+		"2 0 2000000000 1\n500 500\n",                                          //too many drones
+	}
+	for i, testCase := range testCases {
+		if _, err := loadReplay(strings.NewReader(testCase)); err == nil {
+			t.Error("Error in item", i, "Replay should not be loaded")
+		}
+	}
+	if _, err := loadReplay(strings.NewReader("2 0 2 1\n500 500\n-1\n" + turn)); err != nil {
+		t.Error("Well formed replay should be loaded:", err)
+	}
+}
+
+//Tests method restoreTurn
+func TestRestoreTurn(t *testing.T) {
+	rp := loadTestReplay(t)
+	if err := restoreTurn(rp, 2); err != nil {
+		t.Fatal("Error restoring turn:", err)
+	}
+	if turn != 2 || zones[0].owner != 0 || players[0].score != 1 || players[1].drones[0] != (point{3400, 1450}) {
+		t.Error("Wrong status:", importableStatus())
+	}
+	if nextMove[1] != (point{700, 800}) {
+		t.Error("Wrong moves:", nextMove)
+	}
+	if err := restoreTurn(rp, 3); err == nil {
+		t.Error("Turn 3 was not recorded")
+	}
+}
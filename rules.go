@@ -0,0 +1,89 @@
+//Participating Game of Drones by CodinGame - Rules of the game
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	MAX_BOARD_SIDE       = 20000 //Longest side of the boards the bot can play
+	MAX_RULES_DISTANCE   = 1000  //Highest number of turns to cross the board the bot can play
+	RULES_NUM_PARAMETERS = 5     //Number of parameters of the rules in the text format (see parseRules)
+)
+
+var officialRules = newGameRules(4000, 1800, 100.0, 100.0, 200) //Rules of the game in CodinGame
+
+var rules = officialRules //Rules of the current game
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* DATA TYPES BEGIN */
+
+//Rules of a game. The zero value means the official ones
+type gameRules struct {
+	Width       int     `json:"width"`      //Width of the board
+	Height      int     `json:"height"`     //Height of the board
+	ZoneRadius  float64 `json:"zoneRadius"` //Radius of the zones
+	DroneSpeed  float64 `json:"droneSpeed"` //Maximum movement of a drone in a turn
+	MaxTurns    int     `json:"maxTurns"`   //Number of turns of a game
+	maxDistance int     //Number of turns to cross the board. Derived from the rest
+	diagonal    float64 //Length of the diagonal of the board (maximum distance between two points). Derived from the rest
+}
+
+/* DATA TYPES END ******************************************************************** RULES BEGIN */
+
+//Returns the rules with the given parameters and the values derived from them
+func newGameRules(width, height int, zoneRadius, droneSpeed float64, maxTurns int) gameRules {
+	result := gameRules{Width: width, Height: height, ZoneRadius: zoneRadius, DroneSpeed: droneSpeed, MaxTurns: maxTurns}
+	result.diagonal = math.Hypot(float64(width), float64(height))
+	if droneSpeed > 0 {
+		result.maxDistance = int(math.Ceil(result.diagonal / droneSpeed))
+	}
+	return result
+}
+
+//Returns the given rules with the derived values, or the official ones if they are the zero value. Returns an error if
+//they cannot be played
+func completeRules(r gameRules) (gameRules, error) {
+	if r.Width == 0 && r.Height == 0 && r.ZoneRadius == 0 && r.DroneSpeed == 0 && r.MaxTurns == 0 {
+		return officialRules, nil
+	}
+	r = newGameRules(r.Width, r.Height, r.ZoneRadius, r.DroneSpeed, r.MaxTurns)
+	if r.Width <= 0 || r.Height <= 0 || r.Width > MAX_BOARD_SIDE || r.Height > MAX_BOARD_SIDE || r.ZoneRadius <= 0 ||
+		r.DroneSpeed <= 0 || r.MaxTurns <= 0 || r.maxDistance > MAX_RULES_DISTANCE || float64(r.Width) <= 2*r.ZoneRadius ||
+		float64(r.Height) <= 2*r.ZoneRadius {
+		return r, fmt.Errorf("wrong rules: %v", r)
+	}
+	return r, nil
+}
+
+//Parses rules in the format of String
+func parseRules(s string) (gameRules, error) {
+	fields := strings.Split(s, ",")
+	if len(fields) != RULES_NUM_PARAMETERS {
+		return gameRules{}, fmt.Errorf("wrong rules %q: %d parameters instead of %d", s, len(fields), RULES_NUM_PARAMETERS)
+	}
+	values := make([]float64, len(fields))
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil {
+			return gameRules{}, fmt.Errorf("wrong rules %q: %v", s, err)
+		}
+		values[i] = v
+	}
+	return completeRules(gameRules{Width: int(values[0]), Height: int(values[1]), ZoneRadius: values[2], DroneSpeed: values[3], MaxTurns: int(values[4])})
+}
+
+//Returns whether the coordinates are inside the board
+func (r gameRules) inside(c [2]int) bool {
+	return c[0] >= 0 && c[0] < r.Width && c[1] >= 0 && c[1] < r.Height
+}
+
+//Returns the rules as <width>,<height>,<zone radius>,<drone speed>,<turns>
+func (r gameRules) String() string {
+	return fmt.Sprint(r.Width, ",", r.Height, ",", r.ZoneRadius, ",", r.DroneSpeed, ",", r.MaxTurns)
+}
+
+/* RULES END */
@@ -0,0 +1,68 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+//Tests that the values derived from the rules are right
+func TestNewGameRules(t *testing.T) {
+	var testCases = []struct {
+		in          gameRules
+		maxDistance int
+		diagonal    float64
+	}{
+		{officialRules, 44, 4386.34},
+		{newGameRules(8000, 3600, 100.0, 200.0, 200), 44, 8772.68},
+		{newGameRules(4000, 1800, 100.0, 50.0, 200), 88, 4386.34},
+		{newGameRules(300, 400, 10.0, 100.0, 10), 5, 500.0},
+	}
+	for i, tc := range testCases {
+		if tc.in.maxDistance != tc.maxDistance || math.Abs(tc.in.diagonal-tc.diagonal) > 0.01 {
+			t.Error("Error in item", i, "Got", tc.in.maxDistance, tc.in.diagonal, "Expected", tc.maxDistance, tc.diagonal)
+		}
+	}
+}
+
+//Tests the text format of the rules
+func TestParseRules(t *testing.T) {
+	if r, err := parseRules(officialRules.String()); err != nil || r != officialRules {
+		t.Error("The official rules should be parsed back:", officialRules, r, err)
+	}
+	if r, err := parseRules("8000, 3600, 150.5, 200, 300"); err != nil || r != newGameRules(8000, 3600, 150.5, 200.0, 300) {
+		t.Error("Wrong rules:", r, err)
+	}
+	for _, wrong := range []string{"", "4000,1800,100,100", "4000,1800,100,100,200,1", "4000,1800,a,100,200", "0,1800,100,100,200",
+		"4000,1800,100,0,200", "4000,1800,100,100,-1", "4000,1800,100,0.1,200", "40000,1800,100,100,200",
+		"4000,200,100,100,200", "150,1800,100,100,200"} {
+		if _, err := parseRules(wrong); err == nil {
+			t.Error("Wrong rules should be rejected:", wrong)
+		}
+	}
+}
+
+//Tests that the bot plays a larger board with faster drones
+func TestGameBotOtherRules(t *testing.T) {
+	defer func() { rules = officialRules }()
+	board := botBoard{2, 0, 2, [][2]int{{500, 500}, {6000, 3000}}, newGameRules(8000, 3600, 100.0, 150.0, 300)}
+	var bot Bot = gameBot{}
+	if err := bot.Init(board); err != nil || rules != board.Rules {
+		t.Fatal("Error starting the game:", err, rules)
+	}
+	moves, err := bot.Turn(botTurn{[]int{-1, 1}, [][][2]int{{{1000, 500}, {6000, 3400}}, {{7900, 3500}, {6000, 3000}}}})
+	if err != nil {
+		t.Fatal("Error playing the turn:", err)
+	}
+	if distances[0][1][1] != 2 || len(arrivals[0][1]) != rules.maxDistance+1 {
+		t.Error("Distances should follow the rules:", distances[0][1][1], len(arrivals[0][1]))
+	}
+	for dId, m := range moves.Moves {
+		if !rules.inside(m) {
+			t.Error("Move of drone", dId, "out of the board:", m)
+		}
+	}
+	if _, err := bot.Turn(botTurn{[]int{-1, 1}, [][][2]int{{{1000, 500}, {6000, 3400}}, {{8000, 3500}, {6000, 3000}}}}); err == nil {
+		t.Error("Drones out of the board of the rules should be rejected")
+	}
+}
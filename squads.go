@@ -0,0 +1,238 @@
+//Participating Game of Drones by CodinGame - Squads of drones with persistent roles
+package main
+
+import (
+	"fmt"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const ( //Roles of the squads
+	GARRISON = iota //Keeps the air superiority in one of my zones
+	RAIDER          //Attacks a zone I do not own
+	RESERVE         //Every drone without a task. There is always one reserve squad: squads[0]
+)
+
+var squads []squad //Task forces my drones belong to. Membership is kept across turns. squads[0] is the reserve
+
+var hiddenAvailability map[int]int //Real availability of the drones hidden from the running behaviour (see runBehaviour)
+
+var squadBehaviours = []squadBehaviour{ //What the squads do, in order of priority
+	{"hold the zone", strategyMaintainAirSuperiority, []int{GARRISON}},
+	{"opening", strategyOpening, []int{RESERVE}},
+	{"cancel hopeless attacks", strategyRegroupFromHopelessAttacks, []int{RAIDER}},
+	{"capture vacated zones", strategyCaptureVacatedZones, []int{RESERVE}},
+	{"attack", strategyChosenAttack, []int{RAIDER, RESERVE}},
+	{"rally", strategyDefaultToRallyPoints, []int{RESERVE}},
+	{"station", strategyStationFreeDrones, []int{RAIDER}},
+}
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* DATA TYPES BEGIN */
+
+//Task force of my drones
+type squad struct {
+	name    string   //Name of the squad, e.g. "garrison of zone 3"
+	role    int      //GARRISON, RAIDER or RESERVE
+	target  int      //Zone the squad holds or attacks. -1 for the reserve
+	members droneSet //Drones that belong to the squad
+}
+
+//Strategy run by the squads with some roles. It can only move the drones of those squads
+type squadBehaviour struct {
+	name  string //Name of the behaviour
+	run   func() //Strategy that is run
+	roles []int  //Roles of the squads that run it
+}
+
+/* DATA TYPES END ******************************************************************** SQUADS BEGIN */
+
+//Puts all my drones in the reserve. Called at the beginning of each game
+func resetSquads() {
+	squads = []squad{{"reserve", RESERVE, -1, 0}}
+	for dId := 0; dId < numDronesPerplayer; dId += 1 {
+		squads[0].members.add(dId)
+	}
+}
+
+//Decides the moves of the turn: updates the garrisons, runs each behaviour with the drones of its squads and enlists
+//the drones of the attacks launched in this turn as raiders
+func runSquads() {
+	safeRun("garrisons", updateGarrisons)
+	for _, b := range squadBehaviours {
+		trace("Squad behaviour", b.name)
+		runBehaviour(b.name, b.run, squadMembers(b.roles...))
+	}
+	safeRun("raiders", updateRaiders)
+	removeEmptySquads()
+	trace("Squads", squads)
+}
+
+//Runs the strategy allowing it to move only the given drones: the rest of the unassigned drones are hidden as assigned
+//while it runs. If it panics, it is skipped (see safeRun)
+func runBehaviour(name string, strategy func(), allowed droneSet) {
+	hiddenAvailability = make(map[int]int)
+	for dId := 0; dId < numDronesPerplayer; dId += 1 {
+		if !allowed.contains(dId) && !isAssigned(dId) {
+			hiddenAvailability[dId] = availability.drones[dId]
+			availability.drones[dId] = 0
+		}
+	}
+	safeRun(name, strategy)
+	for dId, dist := range hiddenAvailability {
+		availability.drones[dId] = dist
+	}
+	hiddenAvailability = nil
+}
+
+//Returns the distance the drone can safely fly, even if it is hidden from the running behaviour (see runBehaviour)
+func unhiddenAvailableDistance(dId int) int {
+	if dist, hidden := hiddenAvailability[dId]; hidden {
+		return dist
+	}
+	return availableDistance(dId)
+}
+
+//Adjusts each garrison to the drones its zone needs: as many of my drones in the zone as drones of the strongest enemy
+//in it. Members that are still in the zone are kept first, then the rest of my drones in it are recruited (lowest ID
+//first). Garrisons of zones I lost and the drones they do not need go to the reserve
+func updateGarrisons() {
+	for sId, s := range squads {
+		if s.role == GARRISON && zones[s.target].owner != whoami {
+			transferSquad(sId, 0, "zone lost")
+		}
+	}
+	for zId, z := range zones {
+		if z.owner != whoami {
+			continue
+		}
+		inZone := playerDronesNearZone(whoami, zId, 0)
+		need := minInt(mostDronesBySingleOponentInZone(zId), inZone.count())
+		sId := findSquad(GARRISON, zId)
+		if sId < 0 && need == 0 {
+			continue
+		}
+		if sId < 0 {
+			sId = newSquad(GARRISON, zId)
+		}
+		kept := newDroneSet()
+		for dId := squads[sId].members.next(-1); dId >= 0; dId = squads[sId].members.next(dId) {
+			if inZone.contains(dId) && kept.count() < need {
+				kept.add(dId)
+			} else {
+				transfer(dId, 0, "not needed in the garrison")
+			}
+		}
+		for dId := inZone.next(-1); dId >= 0 && kept.count() < need; dId = inZone.next(dId) {
+			if !kept.contains(dId) && squads[squadOf(dId)].role != GARRISON {
+				transfer(dId, sId, "its zone needs it")
+				kept.add(dId)
+			}
+		}
+	}
+}
+
+//Enlists the drones of each attack launched in this turn in the raiders of its target. Raiders whose attack was not
+//launched again go to the reserve
+func updateRaiders() {
+	for _, plan := range attackPlans {
+		sId := findSquad(RAIDER, plan.target)
+		if sId < 0 {
+			sId = newSquad(RAIDER, plan.target)
+		}
+		for dId := plan.force.next(-1); dId >= 0; dId = plan.force.next(dId) {
+			if squadOf(dId) != sId && squads[squadOf(dId)].role != GARRISON {
+				transfer(dId, sId, fmt.Sprint("attacking zone ", plan.target))
+			}
+		}
+	}
+	for sId, s := range squads {
+		if s.role == RAIDER && !isAttacked(s.target) {
+			transferSquad(sId, 0, "attack finished")
+		}
+	}
+}
+
+//Returns true iff an attack to the zone was launched in this turn
+func isAttacked(zId int) bool {
+	for _, plan := range attackPlans {
+		if plan.target == zId {
+			return true
+		}
+	}
+	return false
+}
+
+//Moves a drone to the given squad
+func transfer(dId, to int, reason string) {
+	from := squadOf(dId)
+	if from == to {
+		return
+	}
+	if from >= 0 {
+		squads[from].members.remove(dId)
+		trace("Drone", dId, "leaves", squads[from].name)
+	}
+	squads[to].members.add(dId)
+	trace("Drone", dId, "joins", squads[to].name, ":", reason)
+}
+
+//Moves all the drones of a squad to another one
+func transferSquad(from, to int, reason string) {
+	for dId := squads[from].members.next(-1); dId >= 0; dId = squads[from].members.next(dId) {
+		transfer(dId, to, reason)
+	}
+}
+
+//Returns the squad of the given drone, or -1 if it has none
+func squadOf(dId int) int {
+	for sId, s := range squads {
+		if s.members.contains(dId) {
+			return sId
+		}
+	}
+	return -1
+}
+
+//Returns the squad with the given role and target, or -1 if there is none
+func findSquad(role, target int) int {
+	for sId, s := range squads {
+		if s.role == role && s.target == target {
+			return sId
+		}
+	}
+	return -1
+}
+
+//Creates an empty squad and returns its index
+func newSquad(role, target int) int {
+	name := fmt.Sprint("garrison of zone ", target)
+	if role == RAIDER {
+		name = fmt.Sprint("raiders of zone ", target)
+	}
+	squads = append(squads, squad{name, role, target, 0})
+	return len(squads) - 1
+}
+
+//Removes the squads without drones (but the reserve)
+func removeEmptySquads() {
+	result := squads[:1]
+	for _, s := range squads[1:] {
+		if s.members.count() > 0 {
+			result = append(result, s)
+		}
+	}
+	squads = result
+}
+
+//Returns the drones of the squads with any of the given roles
+func squadMembers(roles ...int) (result droneSet) {
+	for _, s := range squads {
+		for _, role := range roles {
+			if s.role == role {
+				result = result.union(s.members)
+			}
+		}
+	}
+	return result
+}
+
+/* SQUADS END */
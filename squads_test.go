@@ -0,0 +1,93 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"testing"
+)
+
+//Tests that a garrison is formed with as many drones as the enemies in my zone
+func TestSquadsGarrison(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"maintainAirSuperiority\\inputOwned2Vs1.txt", t)
+	calculateDonesAvailableDistances()
+	runSquads()
+	sId := findSquad(GARRISON, 2)
+	if sId < 0 || squads[sId].members != newDroneSet(0) {
+		t.Fatal("Drone 0 should garrison zone 2. Squads:", squads)
+	}
+	if nextMove[0] != zones[2].pos {
+		t.Error("The garrison should hold the zone", nextMove)
+	}
+	if squadOf(1) == sId || squadOf(2) == sId {
+		t.Error("The rest of the drones should not be in the garrison", squads)
+	}
+}
+
+//Tests that the members of a garrison are kept while they are in the zone, even if other drones could replace them
+func TestSquadsStableGarrison(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"maintainAirSuperiority\\inputOwned2Vs1.txt", t)
+	updateGarrisons()
+	sId := findSquad(GARRISON, 2)
+	transfer(0, 0, "test")
+	transfer(1, sId, "test")
+	updateGarrisons()
+	if squads[sId].members != newDroneSet(1) {
+		t.Error("Drone 1 should keep its place in the garrison. Got", squads[sId].members)
+	}
+	zones[2].owner = 1
+	updateGarrisons()
+	removeEmptySquads()
+	if len(squads) != 1 || squads[0].members.count() != numDronesPerplayer {
+		t.Error("All the drones should go to the reserve when the zone is lost. Got", squads)
+	}
+}
+
+//Tests that runBehaviour only lets the strategy move the allowed drones
+func TestRunBehaviour(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"maintainAirSuperiority\\inputOwned2Vs1.txt", t)
+	calculateDonesAvailableDistances()
+	before := availableDistance(2)
+	runBehaviour("station", strategyStationFreeDrones, newDroneSet(1))
+	if !isAssigned(1) || isAssigned(2) {
+		t.Error("Only drone 1 should have been moved", availability)
+	}
+	if availableDistance(2) != before {
+		t.Error("The availability of hidden drones should be restored. Got", availableDistance(2), "Expected", before)
+	}
+}
+
+//Tests that every drone belongs to exactly one squad, and the drones of each attack to its raiders, in every test input
+func TestSquadsMembership(t *testing.T) {
+	for _, path := range gameInputs(t) {
+		setUpTestFromFile(path, t)
+		captureOutput(play, t)
+		all := newDroneSet()
+		for _, s := range squads {
+			if all&s.members != 0 {
+				t.Error(path, "Drones in several squads:", squads)
+			}
+			all = all.union(s.members)
+		}
+		if all.count() != numDronesPerplayer {
+			t.Error(path, "Every drone should belong to a squad:", squads)
+		}
+		for _, plan := range attackPlans {
+			raiders := findSquad(RAIDER, plan.target)
+			if raiders < 0 || plan.force.difference(squadMembers(GARRISON)).difference(squads[raiders].members) != 0 {
+				t.Error(path, "The drones attacking zone", plan.target, "should be its raiders:", plan.force, squads)
+			}
+		}
+	}
+}
+
+//Tests that the drones hidden from a behaviour still count as able to reach a zone
+func TestDronesAbleToReachZoneWithHiddenDrones(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"attackable\\inputSimultaneousArrival.txt", t)
+	var able int
+	runBehaviour("test", func() { able = dronesAbleToReachZone(0, 5) }, newDroneSet(0))
+	if able != 2 {
+		t.Error("Drone 1 is hidden but free to reach zone 0. Got", able, "Expected", 2)
+	}
+	if hiddenAvailability != nil || isAssigned(1) {
+		t.Error("The hidden drones should be restored", hiddenAvailability, availability)
+	}
+}
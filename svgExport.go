@@ -0,0 +1,136 @@
+//Participating Game of Drones by CodinGame - SVG snapshots of the board
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	SVG_WIDTH        = 1000 //Default width of the SVG images in pixels
+	SVG_LEGEND_WIDTH = 220  //Width of the legend at the right of the board in pixels
+	SVG_DRONE_RADIUS = 25.0 //Radius of the drones in board units
+)
+
+var svgPlayerColours = [MAX_PLAYERS]string{"#d62728", "#2ca02c", "#1f77b4", "#ff7f0e", "#9467bd", "#8c564b", "#e377c2", "#17becf"} //Colour of each player. Array index = player's ID
+
+const SVG_UNRECLAIMED_COLOUR = "#999999" //Colour of the zones nobody owns
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* SVG EXPORT BEGIN */
+
+//Returns the current status of the board (zones, owners, drones, my destinations and my attack forces) as an SVG
+//image width pixels wide, with a legend at its right
+func svgSnapshot(width int) string {
+	scale := float64(width) / float64(rules.Width)
+	height := int(float64(rules.Height) * scale)
+	var result bytes.Buffer
+	result.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="monospace" font-size="12">`+"\n",
+		width+SVG_LEGEND_WIDTH, maxInt(height, svgLegendHeight())))
+	result.WriteString(`<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto">` +
+		`<path d="M0,0 L10,5 L0,10 z"/></marker></defs>` + "\n")
+	result.WriteString(fmt.Sprintf(`<rect x="0" y="0" width="%d" height="%d" fill="#ffffff" stroke="#000000"/>`+"\n", width, height))
+	result.WriteString(fmt.Sprintf(`<g transform="scale(%g)">`+"\n", scale))
+	for zId, z := range zones {
+		result.WriteString(fmt.Sprintf(`<circle class="zone" cx="%d" cy="%d" r="%g" fill="%s" fill-opacity="0.3" stroke="%s"/>`+"\n",
+			z.pos.x, z.pos.y, rules.ZoneRadius, svgColour(z.owner), svgColour(z.owner)))
+		result.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-size="%g" text-anchor="middle">%d</text>`+"\n",
+			z.pos.x, z.pos.y+int(rules.ZoneRadius)+int(14/scale), 12/scale, zId))
+	}
+	forces := attackForces()
+	for dId, d := range players[whoami].drones {
+		if dId >= len(nextMove) || nextMove[dId] == NO_MOVE || turnBasedDistance(d, nextMove[dId]) == 0 {
+			continue
+		}
+		dash := ""
+		if _, attacking := forces[dId]; attacking {
+			dash = ` stroke-dasharray="30,15"`
+		}
+		result.WriteString(fmt.Sprintf(`<line class="move" x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="8"%s marker-end="url(#arrow)"/>`+"\n",
+			d.x, d.y, nextMove[dId].x, nextMove[dId].y, svgColour(whoami), dash))
+	}
+	for pId, p := range players {
+		for dId, d := range p.drones {
+			stroke := "none"
+			if pId == whoami {
+				stroke = "#000000"
+			}
+			result.WriteString(fmt.Sprintf(`<circle class="drone" cx="%d" cy="%d" r="%g" fill="%s" stroke="%s" stroke-width="6"><title>player %d drone %d</title></circle>`+"\n",
+				d.x, d.y, SVG_DRONE_RADIUS, svgColour(pId), stroke, pId, dId))
+		}
+	}
+	result.WriteString("</g>\n")
+	result.WriteString(svgLegend(width+10, forces))
+	result.WriteString("</svg>\n")
+	return result.String()
+}
+
+//Writes the SVG snapshot of the current status of the board into the given file
+func writeSVG(path string, width int) error {
+	return os.WriteFile(path, []byte(svgSnapshot(width)), 0644)
+}
+
+//Returns the legend of the snapshot, starting at the given x: turn, players and attack forces
+func svgLegend(x int, forces map[int]int) string {
+	var result bytes.Buffer
+	y := 20
+	result.WriteString(fmt.Sprintf(`<text x="%d" y="%d">Turn %d</text>`+"\n", x, y, turn))
+	for pId, p := range players {
+		y += 20
+		name := ""
+		if pId == whoami {
+			name = " (ME)"
+		}
+		result.WriteString(fmt.Sprintf(`<circle cx="%d" cy="%d" r="6" fill="%s"/><text x="%d" y="%d">player %d%s score: %d</text>`+"\n",
+			x+6, y-4, svgColour(pId), x+18, y, pId, name, p.score))
+	}
+	y += 20
+	result.WriteString(fmt.Sprintf(`<circle cx="%d" cy="%d" r="6" fill="%s"/><text x="%d" y="%d">unreclaimed</text>`+"\n",
+		x+6, y-4, SVG_UNRECLAIMED_COLOUR, x+18, y))
+	for zId, _ := range zones {
+		force := 0
+		for _, target := range forces {
+			if target == zId {
+				force++
+			}
+		}
+		if force > 0 {
+			y += 20
+			result.WriteString(fmt.Sprintf(`<text class="attack" x="%d" y="%d">attack zone %d: %d drones</text>`+"\n", x, y, zId, force))
+		}
+	}
+	return result.String()
+}
+
+//Returns the height in pixels the legend needs in the worst case
+func svgLegendHeight() int {
+	return 20 * (numPlayers + numZones + 3)
+}
+
+//Returns my drones that are going to the centre of a zone I do not own, and the zone each one is attacking
+func attackForces() map[int]int {
+	result := make(map[int]int)
+	for dId, d := range players[whoami].drones {
+		if dId >= len(nextMove) {
+			break
+		}
+		for zId, z := range zones {
+			if z.owner != whoami && nextMove[dId] == z.pos && turnBasedDistance(d, z.pos) > 0 {
+				result[dId] = zId
+				break
+			}
+		}
+	}
+	return result
+}
+
+//Returns the colour of the given player (grey for unreclaimed zones)
+func svgColour(pId int) string {
+	if pId < 0 {
+		return SVG_UNRECLAIMED_COLOUR
+	}
+	return svgPlayerColours[pId%len(svgPlayerColours)]
+}
+
+/* SVG EXPORT END */
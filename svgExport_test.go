@@ -0,0 +1,76 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//Tests method svgSnapshot
+func TestSVGSnapshot(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	zones[2].owner = 1
+	assignDestinationZone(1, 3, "I say so")
+	assignDestinationPoint(0, point{1000, 1000})
+	image := svgSnapshot(SVG_WIDTH)
+	if !strings.HasPrefix(image, "<svg") || !strings.HasSuffix(image, "</svg>\n") {
+		t.Error("Not an SVG image:", image)
+	}
+	if result := strings.Count(image, `class="zone"`); result != numZones {
+		t.Error("Wrong number of zones:", result)
+	}
+	if result := strings.Count(image, `class="drone"`); result != numPlayers*numDronesPerplayer {
+		t.Error("Wrong number of drones:", result)
+	}
+	if result := strings.Count(image, `class="move"`); result != 2 {
+		t.Error("Wrong number of moves:", result)
+	}
+	if !strings.Contains(image, "attack zone 3: 1 drones") {
+		t.Error("Attack to zone 3 should be in the legend")
+	}
+	if !strings.Contains(image, `fill="`+svgColour(1)+`" fill-opacity="0.3"`) {
+		t.Error("Zone 2 should be coloured as player 1")
+	}
+}
+
+//Tests method attackForces
+func TestAttackForces(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	assignDestinationZone(0, 0, "Already there")
+	assignDestinationZone(1, 2, "Attack")
+	forces := attackForces()
+	if len(forces) != 1 || forces[1] != 2 {
+		t.Error("Only drone 1 should be attacking zone 2", forces)
+	}
+}
+
+//Tests the svg command
+func TestCommandSVG(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "turn.svg")
+	replayPath := strings.Replace(REPLAY_TEST_FILE, "\\", "/", -1)
+	if code := commandSVG([]string{replayPath, "2", output}); code != EXIT_OK {
+		t.Fatal("Wrong exit code:", code)
+	}
+	if content, err := os.ReadFile(output); err != nil || !strings.Contains(string(content), "Turn 2") {
+		t.Error("Wrong image:", err, string(content))
+	}
+	if code := commandSVG([]string{replayPath, "3", output}); code != EXIT_ERROR {
+		t.Error("Turn 3 was not recorded. Exit code:", code)
+	}
+	if code := commandSVG([]string{replayPath}); code != EXIT_USAGE {
+		t.Error("Turn is mandatory. Exit code:", code)
+	}
+}
+
+//Tests that every player has its own colour, different from the one of the unreclaimed zones
+func TestSVGPlayerColours(t *testing.T) {
+	for pId := 0; pId < MAX_PLAYERS; pId += 1 {
+		for other := -1; other < pId; other += 1 {
+			if svgColour(pId) == svgColour(other) {
+				t.Error("Players", other, "and", pId, "have the same colour")
+			}
+		}
+	}
+}
@@ -0,0 +1,322 @@
+//Participating Game of Drones by CodinGame - Self-play training of the evaluation weights
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	EVAL_WEIGHTS_PATH   = "weights.txt" //File with the evaluation weights the bot loads, if it exists
+	TRAIN_STEP_TURNS    = 4             //Number of turns between two decisions of the training games
+	TRAIN_LEARNING_RATE = 0.01          //Step of the updates of the weights
+	TRAIN_LAMBDA        = 0.7           //Decay of the eligibility traces of TD(lambda)
+	TRAIN_EXPLORATION   = 0.1           //Probability of choosing a random action in the training games
+	TRAIN_MAX_ZONES     = 8             //Maximum number of zones of the training games
+	TRAIN_MAX_DRONES    = 11            //Maximum number of drones per player of the training games
+)
+
+var evalFeatureNames = []string{"zones", "projection", "score", "finalLead", "spare", "vulnerability"} //Names of the features in the weights files, in the order of evalFeatures
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* DATA TYPES BEGIN */
+
+//Status of a training run
+type trainer struct {
+	rng     *rand.Rand //Source of random numbers. The only one, so runs are reproducible from the seed
+	weights []float64  //Weights of the features divided by their scales (see featureScales)
+}
+
+/* DATA TYPES END ******************************************************************** TRAINING BEGIN */
+
+//Trains the evaluation weights with the given number of self-play games and seed, saving them into the given file
+//(EVAL_WEIGHTS_PATH by default). The games follow the given rules (the official ones by default). The weights are also
+//printed as a Go literal to embed them
+func commandTrain(args []string) int {
+	if len(args) < 2 || len(args) > 4 {
+		return EXIT_USAGE
+	}
+	games, err := strconv.Atoi(args[0])
+	if err != nil || games <= 0 {
+		return EXIT_USAGE
+	}
+	seed, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return EXIT_USAGE
+	}
+	path := EVAL_WEIGHTS_PATH
+	if len(args) >= 3 {
+		path = args[2]
+	}
+	if len(args) == 4 {
+		if rules, err = parseRules(args[3]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error parsing rules:", err)
+			return EXIT_USAGE
+		}
+	}
+	w := trainWeights(games, seed, evaluationWeights)
+	if err := saveEvalWeightsFile(path, w); err != nil {
+		fmt.Fprintln(os.Stderr, "Error saving weights:", err)
+		return EXIT_ERROR
+	}
+	fmt.Println("var evaluationWeights =", strings.TrimPrefix(fmt.Sprintf("%#v", w), "main."))
+	return EXIT_OK
+}
+
+//Returns the weights learnt with TD(lambda) from the given ones playing the given number of self-play games
+func trainWeights(games int, seed int64, initial evalWeights) evalWeights {
+	tr := trainer{rand.New(rand.NewSource(seed)), initial.vector()}
+	scales := featureScales()
+	for i, _ := range tr.weights {
+		tr.weights[i] *= scales[i]
+	}
+	for game := 0; game < games; game += 1 {
+		tr.randomBoard()
+		tr.playGame()
+	}
+	for i, _ := range tr.weights {
+		tr.weights[i] /= scales[i]
+	}
+	return evalWeightsFromVector(tr.weights)
+}
+
+//Sets up a random game of 2 to 4 players, 3 to TRAIN_MAX_DRONES drones and 4 to TRAIN_MAX_ZONES zones with the drones
+//at random positions
+func (tr *trainer) randomBoard() {
+	numPlayers, whoami = 2+tr.rng.Intn(3), 0
+	numDronesPerplayer, numZones = 3+tr.rng.Intn(TRAIN_MAX_DRONES-2), 4+tr.rng.Intn(TRAIN_MAX_ZONES-3)
+	zones = make([]zone, numZones)
+	for zId, _ := range zones {
+		zones[zId] = zone{tr.randomPoint(int(rules.ZoneRadius)), UNRECLAIMED}
+	}
+	players = make([]player, numPlayers)
+	for pId, _ := range players {
+		players[pId].drones = make([]point, numDronesPerplayer)
+		for dId, _ := range players[pId].drones {
+			players[pId].drones[dId] = tr.randomPoint(0)
+		}
+	}
+	turn = 0
+}
+
+//Returns a random point of the board at least margin units away from its borders
+func (tr *trainer) randomPoint(margin int) point {
+	return point{margin + tr.rng.Intn(rules.Width-2*margin), margin + tr.rng.Intn(rules.Height-2*margin)}
+}
+
+//Plays a game where every player chooses, every TRAIN_STEP_TURNS turns, the action that looks best with the current
+//weights (or a random one with probability TRAIN_EXPLORATION). After each decision, the weights are updated from the
+//point of view of every player with TD(lambda). The result of the game (1 for the only winner, -1 for the losers and 0
+//for shared victories) is the only reward
+func (tr *trainer) playGame() {
+	s := currentSimState()
+	sr := &search{order: make([]int, numPlayers), movable: make([]droneSet, numPlayers)}
+	for pId, _ := range sr.movable {
+		sr.order[pId] = pId
+		sr.movable[pId] = droneSet(1<<uint(numDronesPerplayer) - 1)
+	}
+	traces := make([][]float64, numPlayers)
+	values := make([]float64, numPlayers)
+	for pId, _ := range traces {
+		traces[pId] = make([]float64, len(tr.weights))
+		values[pId] = tr.learn(s, pId, traces[pId], math.NaN())
+	}
+	for s.turn < rules.MaxTurns {
+		for pId, _ := range players {
+			s.apply(pId, tr.chooseAction(s, sr, pId))
+		}
+		s.advance(TRAIN_STEP_TURNS)
+		for pId, _ := range players {
+			if s.turn < rules.MaxTurns {
+				values[pId] = tr.learn(s, pId, traces[pId], values[pId])
+			} else {
+				tr.update(traces[pId], gameResult(s, pId)-values[pId])
+			}
+		}
+	}
+}
+
+//Updates the weights with the temporal difference between the value of the previous state of the player (NaN in the
+//first state) and the value of the current one, adds the gradient of the current one to the traces and returns its value
+func (tr *trainer) learn(s simState, pId int, traces []float64, previous float64) float64 {
+	x := scaledFeatures(s, pId)
+	value := tr.value(x)
+	if !math.IsNaN(previous) {
+		tr.update(traces, value-previous)
+	}
+	for i, _ := range traces {
+		traces[i] = TRAIN_LAMBDA*traces[i] + (1-value*value)*x[i]
+	}
+	return value
+}
+
+//Moves the weights along the traces in proportion to the temporal difference
+func (tr *trainer) update(traces []float64, delta float64) {
+	for i, _ := range tr.weights {
+		tr.weights[i] += TRAIN_LEARNING_RATE * delta * traces[i]
+	}
+}
+
+//Returns the action of the player: a random one with probability TRAIN_EXPLORATION, or the one whose state after
+//TRAIN_STEP_TURNS turns (the rest of the players doing nothing new) has the highest value
+func (tr *trainer) chooseAction(s simState, sr *search, pId int) searchAction {
+	actions := sr.actions(s, pId)
+	if tr.rng.Float64() < TRAIN_EXPLORATION {
+		return actions[tr.rng.Intn(len(actions))]
+	}
+	best, bestValue := actions[0], math.Inf(-1)
+	for _, a := range actions {
+		child := s.clone()
+		child.apply(pId, a)
+		child.advance(TRAIN_STEP_TURNS)
+		if v := tr.value(scaledFeatures(child, pId)); v > bestValue {
+			best, bestValue = a, v
+		}
+	}
+	return best
+}
+
+//Returns the estimated result of the game for the given scaled features: tanh of the weighted sum
+func (tr *trainer) value(x []float64) float64 {
+	sum := 0.0
+	for i, _ := range x {
+		sum += tr.weights[i] * x[i]
+	}
+	return math.Tanh(sum)
+}
+
+//Returns 1 if the player is the only one with the highest score, 0 if it shares it and -1 otherwise
+func gameResult(s simState, pId int) float64 {
+	best, winners := math.MinInt32, 0
+	for _, score := range s.scores {
+		if score > best {
+			best, winners = score, 1
+		} else if score == best {
+			winners++
+		}
+	}
+	if s.scores[pId] < best {
+		return -1
+	}
+	if winners > 1 {
+		return 0
+	}
+	return 1
+}
+
+//Returns the features of the state divided by their scales
+func scaledFeatures(s simState, pId int) []float64 {
+	result := stateFeatures(s, pId).vector()
+	scales := featureScales()
+	for i, _ := range result {
+		result[i] /= scales[i]
+	}
+	return result
+}
+
+//Returns the highest absolute value each feature can have in the training games, so scaled features are in [-1, 1]
+func featureScales() []float64 {
+	return evalFeatures{zones: TRAIN_MAX_ZONES, projection: TRAIN_MAX_ZONES, score: float64(rules.MaxTurns * TRAIN_MAX_ZONES),
+		finalLead: float64(rules.MaxTurns * TRAIN_MAX_ZONES), spare: TRAIN_MAX_DRONES, vulnerability: TRAIN_MAX_ZONES}.vector()
+}
+
+/* TRAINING END ******************************************************************** WEIGHTS FILES BEGIN */
+
+//Returns the features in the order of evalFeatureNames
+func (f evalFeatures) vector() []float64 {
+	return []float64{f.zones, f.projection, f.score, f.finalLead, f.spare, f.vulnerability}
+}
+
+//Returns the weights in the order of evalFeatureNames
+func (w evalWeights) vector() []float64 {
+	return evalFeatures(w).vector()
+}
+
+//Returns the weights from their values in the order of evalFeatureNames
+func evalWeightsFromVector(v []float64) evalWeights {
+	return evalWeights{v[0], v[1], v[2], v[3], v[4], v[5]}
+}
+
+//Writes the weights as one line "name value" per feature
+func writeEvalWeights(w io.Writer, weights evalWeights) error {
+	for i, v := range weights.vector() {
+		if _, err := fmt.Fprintf(w, "%s %v\n", evalFeatureNames[i], v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Reads weights written by writeEvalWeights. Features that are not in the input keep the given weights
+func readEvalWeights(r io.Reader, initial evalWeights) (evalWeights, error) {
+	v := initial.vector()
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return initial, fmt.Errorf("wrong line: %q", scanner.Text())
+		}
+		i := indexOf(evalFeatureNames, fields[0])
+		if i < 0 {
+			return initial, fmt.Errorf("unknown feature %q", fields[0])
+		}
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return initial, fmt.Errorf("wrong weight of %s: %v", fields[0], err)
+		}
+		v[i] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return initial, err
+	}
+	return evalWeightsFromVector(v), nil
+}
+
+//Writes the weights into a file
+func saveEvalWeightsFile(path string, weights evalWeights) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := writeEvalWeights(f, weights); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+//Sets the evaluation weights from a file
+func loadEvalWeightsFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w, err := readEvalWeights(f, evaluationWeights)
+	if err != nil {
+		return err
+	}
+	evaluationWeights = w
+	return nil
+}
+
+//Returns the index of the string in the slice, or -1 if it is not there
+func indexOf(ss []string, s string) int {
+	for i, candidate := range ss {
+		if candidate == s {
+			return i
+		}
+	}
+	return -1
+}
+
+/* WEIGHTS FILES END */
@@ -0,0 +1,89 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//Tests that training is reproducible from the seed and learns something
+func TestTrainWeights(t *testing.T) {
+	first := trainWeights(3, 7, evaluationWeights)
+	if second := trainWeights(3, 7, evaluationWeights); first != second {
+		t.Error("Training should be reproducible. Got", first, "and", second)
+	}
+	if first == evaluationWeights {
+		t.Error("Training should change the weights", first)
+	}
+	if other := trainWeights(3, 8, evaluationWeights); other == first {
+		t.Error("Different seeds should play different games", other)
+	}
+}
+
+//Tests method gameResult
+func TestGameResult(t *testing.T) {
+	var testCases = []struct {
+		scores []int
+		pId    int
+		out    float64
+	}{
+		{[]int{10, 5}, 0, 1},
+		{[]int{10, 5}, 1, -1},
+		{[]int{10, 10, 3}, 1, 0},
+		{[]int{10, 10, 3}, 2, -1},
+	}
+	for i, testCase := range testCases {
+		if result := gameResult(simState{scores: testCase.scores}, testCase.pId); testCase.out != result {
+			t.Error("Error in item", i, "Got", result, "Expected", testCase.out, "Case:", testCase)
+		}
+	}
+}
+
+//Tests that weights are read as they are written, and that missing features keep their weights
+func TestEvalWeightsFiles(t *testing.T) {
+	w := evalWeights{zones: 1.5, projection: -2, score: 3, finalLead: 0.25, spare: 5, vulnerability: -6}
+	var buffer bytes.Buffer
+	if err := writeEvalWeights(&buffer, w); err != nil {
+		t.Fatal("Error writing weights:", err)
+	}
+	if result, err := readEvalWeights(&buffer, evaluationWeights); err != nil || result != w {
+		t.Error("Wrong weights read:", result, err)
+	}
+	expected := w
+	expected.score = 7
+	if result, err := readEvalWeights(strings.NewReader("score 7\n\n"), w); err != nil || result != expected {
+		t.Error("Only the score should change:", result, err)
+	}
+	for _, wrong := range []string{"speed 1\n", "score\n", "score x\n"} {
+		if _, err := readEvalWeights(strings.NewReader(wrong), w); err == nil {
+			t.Error("Wrong weights should not be read:", wrong)
+		}
+	}
+}
+
+//Tests that the train command saves the weights it prints
+func TestCommandTrain(t *testing.T) {
+	if code := commandTrain([]string{"x", "1"}); code != EXIT_USAGE {
+		t.Error("Wrong number of games should be a usage error. Got", code)
+	}
+	path := filepath.Join(t.TempDir(), "weights.txt")
+	var code int
+	output := captureOutput(func() { code = commandTrain([]string{"2", "1", path}) }, t)
+	if code != EXIT_OK || !strings.HasPrefix(output, "var evaluationWeights = evalWeights{") {
+		t.Fatal("Wrong result of the command:", code, output)
+	}
+	saved := evaluationWeights
+	defer func() { evaluationWeights = saved }()
+	if err := loadEvalWeightsFile(path); err != nil {
+		t.Fatal("Error loading weights:", err)
+	}
+	if expected := trainWeights(2, 1, saved); evaluationWeights != expected {
+		t.Error("Wrong weights loaded:", evaluationWeights, "Expected", expected)
+	}
+	if err := loadEvalWeightsFile(path + ".missing"); !os.IsNotExist(err) {
+		t.Error("A missing file should be reported as such:", err)
+	}
+}
@@ -0,0 +1,199 @@
+//Participating Game of Drones by CodinGame - Strategic analysis of the zones
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+/************************************************************************************** CONSTANTS AND VARIABLES BEGIN */
+const (
+	CLUSTER_DISTANCE = 6 //Maximum number of turns between two zones of the same cluster
+)
+
+var ( //zone analysis-related variables. Calculated once per game
+	zoneDistances [][]int    //Number of turns between the centres of each pair of zones
+	zoneInfos     []zoneInfo //Strategic information of each zone. Array index = zone's ID
+	clusterSizes  []int      //Number of zones of each cluster. Array index = cluster's ID
+)
+
+/* CONSTANTS AND VARIABLES END ********************************************************************* DATA TYPES BEGIN */
+
+//Strategic information about a zone
+type zoneInfo struct {
+	centrality     float64 //1 for a zone at distance 0 of all others, 0 for a zone at rules.maxDistance of all others
+	neighbours     []int   //IDs of the rest of zones, nearest first
+	cluster        int     //ID of the group of nearby zones the zone belongs to
+	startDistances []int   //Turns the nearest drone of each player needed to reach the zone at the beginning of the game
+}
+
+/* DATA TYPES END ******************************************************************** ZONE ANALYSIS BEGIN */
+
+//Calculates the strategic information of the zones that only depends on the board
+func analyzeZones() {
+	zoneDistances = make([][]int, numZones)
+	for zId, z := range zones {
+		zoneDistances[zId] = make([]int, numZones)
+		for otherId, other := range zones {
+			zoneDistances[zId][otherId] = turnBasedDistance(z.pos, other.pos)
+		}
+	}
+	zoneInfos = make([]zoneInfo, numZones)
+	for zId, _ := range zones {
+		zoneInfos[zId].centrality = calculateCentrality(zId)
+		zoneInfos[zId].neighbours = calculateNeighbours(zId)
+		zoneInfos[zId].cluster = -1
+	}
+	calculateClusters()
+	trace("Zone analysis", zoneInfos)
+}
+
+//Calculates how many turns the players needed to reach each zone with the positions of their drones in the first turn
+func analyzeStartPositions() {
+	for zId, z := range zones {
+		zoneInfos[zId].startDistances = make([]int, numPlayers)
+		for pId, p := range players {
+			zoneInfos[zId].startDistances[pId] = rules.maxDistance
+			for _, d := range p.drones {
+				if dist := turnBasedDistance(d, z.pos); dist < zoneInfos[zId].startDistances[pId] {
+					zoneInfos[zId].startDistances[pId] = dist
+				}
+			}
+		}
+	}
+	trace("Start positions analysis", zoneInfos)
+}
+
+//Returns the centrality of the zone based on the average distance to the rest of zones
+func calculateCentrality(zId int) float64 {
+	if numZones < 2 {
+		return 1.0
+	}
+	total := 0
+	for _, dist := range zoneDistances[zId] {
+		total += dist
+	}
+	average := float64(total) / float64(numZones-1)
+	return math.Max(0.0, 1.0-average/float64(rules.maxDistance))
+}
+
+//Returns the IDs of the rest of zones sorted by distance to the given one. Ties are broken by zone ID
+func calculateNeighbours(zId int) []int {
+	result := make([]int, 0, numZones-1)
+	for otherId, _ := range zones {
+		if otherId != zId {
+			result = append(result, otherId)
+		}
+	}
+	sort.Sort(neighbourSorter{zId, result})
+	return result
+}
+
+//Groups the zones in clusters: two zones belong to the same cluster if there is a chain of zones between them
+//where each zone is at most CLUSTER_DISTANCE turns away from the previous one. Clusters are numbered by their lowest zone ID
+func calculateClusters() {
+	clusterSizes = make([]int, 0, numZones)
+	for zId, _ := range zones {
+		if zoneInfos[zId].cluster >= 0 {
+			continue
+		}
+		cId := len(clusterSizes)
+		clusterSizes = append(clusterSizes, 0)
+		pending := []int{zId}
+		zoneInfos[zId].cluster = cId
+		for len(pending) > 0 {
+			current := pending[0]
+			pending = pending[1:]
+			clusterSizes[cId]++
+			for otherId, _ := range zones {
+				if zoneInfos[otherId].cluster < 0 && zoneDistances[current][otherId] <= CLUSTER_DISTANCE {
+					zoneInfos[otherId].cluster = cId
+					pending = append(pending, otherId)
+				}
+			}
+		}
+	}
+}
+
+//Implements sort.Interface. Sorts zones by distance to a reference zone
+type neighbourSorter struct {
+	reference int   //ID of the zone distances are measured from
+	zIds      []int //IDs of the zones to sort
+}
+
+//Necessary to implement sort.Interface
+func (ns neighbourSorter) Less(i, j int) bool {
+	di, dj := zoneDistances[ns.reference][ns.zIds[i]], zoneDistances[ns.reference][ns.zIds[j]]
+	if di != dj {
+		return di < dj
+	}
+	return ns.zIds[i] < ns.zIds[j]
+}
+
+//Necessary to implement sort.Interface
+func (ns neighbourSorter) Swap(i, j int) {
+	ns.zIds[i], ns.zIds[j] = ns.zIds[j], ns.zIds[i]
+}
+
+//Necessary to implement sort.Interface
+func (ns neighbourSorter) Len() int {
+	return len(ns.zIds)
+}
+
+//Implements sort.Interface. Sorts zones by start advantage, then by distance to their nearest zone (both descending)
+//and then by ID
+type openingSorter []int
+
+//Necessary to implement sort.Interface
+func (zs openingSorter) Less(i, j int) bool {
+	if ai, aj := startAdvantage(zs[i]), startAdvantage(zs[j]); ai != aj {
+		return ai > aj
+	}
+	if di, dj := nearestZoneDistance(zs[i]), nearestZoneDistance(zs[j]); di != dj {
+		return di > dj
+	}
+	return zs[i] < zs[j]
+}
+
+//Necessary to implement sort.Interface
+func (zs openingSorter) Swap(i, j int) {
+	zs[i], zs[j] = zs[j], zs[i]
+}
+
+//Necessary to implement sort.Interface
+func (zs openingSorter) Len() int {
+	return len(zs)
+}
+
+/* ZONE ANALYSIS END ******************************************************************** ZONE QUERIES BEGIN */
+
+//Returns the number of turns between the given zone and its nearest other zone (rules.maxDistance if it is alone)
+func nearestZoneDistance(zId int) int {
+	if len(zoneInfos[zId].neighbours) == 0 {
+		return rules.maxDistance
+	}
+	return zoneDistances[zId][zoneInfos[zId].neighbours[0]]
+}
+
+//Returns how many turns before the fastest oponent I could reach the zone at the beginning of the game.
+//Negative values mean an oponent was nearer
+func startAdvantage(zId int) int {
+	if zoneInfos[zId].startDistances == nil {
+		return 0
+	}
+	nearestOponent := rules.maxDistance
+	for pId, dist := range zoneInfos[zId].startDistances {
+		if pId != whoami && dist < nearestOponent {
+			nearestOponent = dist
+		}
+	}
+	return nearestOponent - zoneInfos[zId].startDistances[whoami]
+}
+
+//Returns the strategic value of the zone, between 0 and 1. Central zones in big clusters are more valuable
+func zoneValue(zId int) float64 {
+	clusterShare := float64(clusterSizes[zoneInfos[zId].cluster]) / float64(numZones)
+	return (zoneInfos[zId].centrality + clusterShare) / 2
+}
+
+/* ZONE QUERIES END */
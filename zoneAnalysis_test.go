@@ -0,0 +1,87 @@
+// Codingame - Game of Drones
+package main
+
+import (
+	"testing"
+)
+
+//Tests method analyzeZones on a board with a cluster of three zones and an isolated one
+func TestAnalyzeZonesClusters(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	expected := []int{0, 0, 1, 0}
+	for zId, cId := range expected {
+		if zoneInfos[zId].cluster != cId {
+			t.Error("Zone", zId, "should belong to cluster", cId, "Got", zoneInfos[zId].cluster)
+		}
+	}
+	if len(clusterSizes) != 2 || clusterSizes[0] != 3 || clusterSizes[1] != 1 {
+		t.Error("Wrong cluster sizes:", clusterSizes)
+	}
+}
+
+//Tests the order of the neighbours of the zones and the distance to the nearest one
+func TestAnalyzeZonesNeighbours(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	var testCases = []struct {
+		zId        int
+		neighbours []int
+		nearest    int
+	}{
+		{0, []int{1, 3, 2}, 3},
+		{2, []int{1, 3, 0}, 27},
+	}
+	for i, testCase := range testCases {
+		for j, nId := range testCase.neighbours {
+			if zoneInfos[testCase.zId].neighbours[j] != nId {
+				t.Error("Error in item", i, "Got", zoneInfos[testCase.zId].neighbours, "Expected", testCase.neighbours)
+				break
+			}
+		}
+		if result := nearestZoneDistance(testCase.zId); result != testCase.nearest {
+			t.Error("Error in item", i, "Got", result, "Expected", testCase.nearest)
+		}
+	}
+}
+
+//Tests that zones inside the cluster are more central and valuable than the isolated one
+func TestZoneValue(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	if zoneInfos[0].centrality <= zoneInfos[2].centrality {
+		t.Error("Zone 0 should be more central than zone 2", zoneInfos)
+	}
+	if zoneValue(0) <= zoneValue(2) {
+		t.Error("Zone 0 should be more valuable than zone 2", zoneValue(0), zoneValue(2))
+	}
+	if zoneValue(2) < 0 || zoneValue(0) > 1 {
+		t.Error("Zone values should be between 0 and 1", zoneValue(0), zoneValue(2))
+	}
+}
+
+//Tests method startAdvantage
+func TestStartAdvantage(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	if result := startAdvantage(0); result != 30 {
+		t.Error("I should reach zone 0 30 turns before my oponent. Got", result)
+	}
+	if result := startAdvantage(2); result != -30 {
+		t.Error("My oponent should reach zone 2 30 turns before me. Got", result)
+	}
+}
+
+//Tests method strategyOpening
+func TestStrategyOpening(t *testing.T) {
+	setUpTestFromFile(FILE_TESTS_BASE+"zoneAnalysis\\input.txt", t)
+	strategyOpening()
+	expected := []point{zones[0].pos, zones[3].pos} //Zone 3 goes before zone 1: my advantage there is bigger
+	for dId, p := range expected {
+		if nextMove[dId] != p {
+			t.Error("Error in item", dId, "Got", nextMove[dId], "Expected", p)
+		}
+	}
+	initializeTurnComputation()
+	turn++
+	strategyOpening()
+	if numAssignedDrones() != 0 {
+		t.Error("The opening is only played in the first turn", nextMove)
+	}
+}